@@ -14,6 +14,7 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 var (
@@ -55,6 +56,8 @@ func main() {
 		case "7":
 			verifyDeletion()
 		case "8":
+			updateJob()
+		case "9":
 			fmt.Println("\nThank you for using Job Search Service!")
 			return
 		default:
@@ -77,7 +80,8 @@ func showMenu() {
 	fmt.Println("5. Search Jobs by Skills")
 	fmt.Println("6. Delete a Job")
 	fmt.Println("7. List All Jobs (Verify)")
-	fmt.Println("8. Exit")
+	fmt.Println("8. Update a Job")
+	fmt.Println("9. Exit")
 	fmt.Println("===========================================")
 }
 
@@ -141,13 +145,26 @@ func searchJobsByQuery() {
 	defer cancel()
 
 	resp, err := client.SearchJobs(ctx, &pb.SearchJobsRequest{
-		Query: query,
+		Query:       query,
+		AutoCorrect: true,
+		Highlight: &pb.HighlightOptions{
+			PreTag:            "[",
+			PostTag:           "]",
+			FragmentSize:      100,
+			NumberOfFragments: 1,
+		},
 	})
 	if err != nil {
 		fmt.Printf("Failed to search jobs: %v\n", err)
 		return
 	}
 
+	if resp.QueryCorrected {
+		fmt.Printf("\nNo results for %q. Showing results for %q instead.\n", query, resp.SuggestedQuery)
+	} else if resp.SuggestedQuery != "" {
+		fmt.Printf("\nDid you mean %q?\n", resp.SuggestedQuery)
+	}
+
 	fmt.Printf("\nFound %d job(s):\n", resp.Total)
 	fmt.Println("-------------------------------------------")
 	for i, job := range resp.Jobs {
@@ -161,6 +178,9 @@ func searchJobsByQuery() {
 		fmt.Printf("   Location: %s\n", job.Location)
 		fmt.Printf("   Salary: $%.0f\n", job.Salary)
 		fmt.Printf("   Skills: %v\n", job.Skills)
+		if snippet := job.Highlights["description"]; snippet != nil && len(snippet.Fragments) > 0 {
+			fmt.Printf("   ...%s...\n", snippet.Fragments[0])
+		}
 		fmt.Printf("   ID: %s\n", job.Id)
 	}
 }
@@ -191,19 +211,37 @@ func getJobByID() {
 	fmt.Printf("Skills:      %v\n", job.Skills)
 	fmt.Printf("Description: %s\n", job.Description)
 	fmt.Printf("Created At:  %s\n", job.CreatedAt)
+	fmt.Printf("Version:     %s\n", job.Version)
 }
 
 func searchByLocation() {
 	fmt.Println("\n--- Search Jobs by Location ---")
 
 	location := readInput("Enter location: ")
+	radiusStr := readInput("Within radius in km (blank for text match): ")
+
+	req := &pb.SearchJobsRequest{
+		Location: location,
+	}
+	if radiusStr != "" {
+		radius, err := strconv.ParseFloat(radiusStr, 64)
+		if err != nil {
+			fmt.Printf("Invalid radius: %v\n", err)
+			return
+		}
+		req = &pb.SearchJobsRequest{
+			GeoDistance: &pb.GeoDistanceFilter{
+				OriginLocation: location,
+				RadiusKm:       radius,
+			},
+			Sort: []*pb.SortOption{{Field: pb.SortField_SORT_FIELD_DISTANCE}},
+		}
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	resp, err := client.SearchJobs(ctx, &pb.SearchJobsRequest{
-		Location: location,
-	})
+	resp, err := client.SearchJobs(ctx, req)
 	if err != nil {
 		fmt.Printf("Failed to search jobs: %v\n", err)
 		return
@@ -220,6 +258,9 @@ func searchByLocation() {
 		}
 		fmt.Printf("   Company: %s\n", job.Company)
 		fmt.Printf("   Salary: $%.0f\n", job.Salary)
+		if req.GeoDistance != nil {
+			fmt.Printf("   Location: %s (%.1f km)\n", job.Location, job.DistanceKm)
+		}
 		fmt.Printf("   ID: %s\n", job.Id)
 	}
 }
@@ -291,26 +332,106 @@ func deleteJob() {
 	fmt.Printf("  %s\n", resp.Message)
 }
 
-func verifyDeletion() {
-	fmt.Println("\n--- All Jobs (Verification) ---")
+func updateJob() {
+	fmt.Println("\n--- Update Job ---")
+	fmt.Println("(leave a field blank to keep its current value)")
+
+	jobID := readInput("Enter Job ID to update: ")
+
+	patch := &pb.Job{}
+	paths := []string{}
+
+	if title := readInput("Job Title: "); title != "" {
+		patch.Title = title
+		paths = append(paths, "title")
+	}
+	if description := readInput("Description: "); description != "" {
+		patch.Description = description
+		paths = append(paths, "description")
+	}
+	if company := readInput("Company: "); company != "" {
+		patch.Company = company
+		paths = append(paths, "company")
+	}
+	if location := readInput("Location: "); location != "" {
+		patch.Location = location
+		paths = append(paths, "location")
+	}
+	if skillsInput := readInput("Skills (comma-separated): "); skillsInput != "" {
+		skills := strings.Split(skillsInput, ",")
+		for i := range skills {
+			skills[i] = strings.TrimSpace(skills[i])
+		}
+		patch.Skills = skills
+		paths = append(paths, "skills")
+	}
+	if salaryStr := readInput("Salary: "); salaryStr != "" {
+		salary, err := strconv.ParseFloat(salaryStr, 64)
+		if err != nil {
+			fmt.Printf("Invalid salary: %v\n", err)
+			return
+		}
+		patch.Salary = salary
+		paths = append(paths, "salary")
+	}
+
+	if len(paths) == 0 {
+		fmt.Println("Nothing to update.")
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	resp, err := client.SearchJobs(ctx, &pb.SearchJobsRequest{
-		Query: "",
+	resp, err := client.PatchJob(ctx, &pb.PatchJobRequest{
+		Id:         jobID,
+		Job:        patch,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
 	})
 	if err != nil {
-		fmt.Printf("Failed to list jobs: %v\n", err)
+		fmt.Printf("Failed to update job: %v\n", err)
 		return
 	}
 
-	fmt.Printf("\nTotal jobs in database: %d\n", resp.Total)
-	fmt.Println("-------------------------------------------")
-	for i, job := range resp.Jobs {
-		fmt.Printf("\n%d. %s\n", i+1, job.Title)
-		fmt.Printf("   Company: %s\n", job.Company)
-		fmt.Printf("   Location: %s\n", job.Location)
-		fmt.Printf("   ID: %s\n", job.Id)
+	fmt.Println("\n✓ Job updated successfully!")
+	fmt.Printf("  Updated fields: %v\n", paths)
+	fmt.Printf("  Updated At: %s\n", resp.Job.UpdatedAt)
+}
+
+func verifyDeletion() {
+	fmt.Println("\n--- All Jobs (Verification) ---")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pageToken := ""
+	count := 0
+	for {
+		resp, err := client.SearchJobs(ctx, &pb.SearchJobsRequest{
+			Query:     "",
+			PageSize:  50,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			fmt.Printf("Failed to list jobs: %v\n", err)
+			return
+		}
+
+		if pageToken == "" {
+			fmt.Printf("\nTotal jobs in database: %d\n", resp.Total)
+			fmt.Println("-------------------------------------------")
+		}
+		for _, job := range resp.Jobs {
+			count++
+			fmt.Printf("\n%d. %s\n", count, job.Title)
+			fmt.Printf("   Company: %s\n", job.Company)
+			fmt.Printf("   Location: %s\n", job.Location)
+			fmt.Printf("   ID: %s\n", job.Id)
+		}
+
+		if resp.NextPageToken == "" {
+			return
+		}
+		pageToken = resp.NextPageToken
 	}
 }
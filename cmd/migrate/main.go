@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"job-search-service/internal/config"
+	"job-search-service/internal/elastic"
+	"job-search-service/internal/migration"
+	"job-search-service/internal/taxonomy"
+)
+
+func main() {
+	configPath := flag.String("config", config.DefaultPath, "path to the config file")
+	statusOnly := flag.Bool("status", false, "print the current mapping version and exit")
+	deleteOld := flag.Bool("delete-old", false, "delete the previous index after a successful migration")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	esClient, err := elastic.NewClient(cfg.Elasticsearch.URL)
+	if err != nil {
+		log.Fatalf("Failed to create Elasticsearch client: %v", err)
+	}
+
+	ctx := context.Background()
+	migrator := migration.NewMigrator(esClient, cfg.Elasticsearch.Index)
+
+	if *statusOnly {
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("Failed to get migration status: %v", err)
+		}
+		switch {
+		case !status.Exists():
+			log.Printf("Alias '%s' does not exist yet (latest mapping version %d)", status.Alias, status.Latest)
+		case status.Legacy:
+			log.Printf("'%s' is a plain index with mapping version %d (latest %d)", status.Alias, status.Version, status.Latest)
+		default:
+			log.Printf("Alias '%s' -> '%s', mapping version %d (latest %d)", status.Alias, status.Index, status.Version, status.Latest)
+		}
+		return
+	}
+
+	skills, err := taxonomy.Load(cfg.Skills.Taxonomy)
+	if err != nil {
+		log.Fatalf("Failed to load skill taxonomy: %v", err)
+	}
+	if err := esClient.PutSynonymsSet(ctx, elastic.SkillSynonymsSet, skills.SynonymRules()); err != nil {
+		log.Fatalf("Failed to update skill synonyms: %v", err)
+	}
+
+	previous, err := migrator.Migrate(ctx)
+	if err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+
+	if previous != "" {
+		if *deleteOld {
+			if err := esClient.DeleteIndex(ctx, previous); err != nil {
+				log.Fatalf("Failed to delete previous index: %v", err)
+			}
+		} else {
+			log.Printf("Previous index '%s' kept for rollback, rerun with -delete-old or delete it manually", previous)
+		}
+	}
+
+	log.Println("Migration complete")
+}
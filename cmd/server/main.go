@@ -9,64 +9,93 @@ import (
 	"os/signal"
 	"syscall"
 
+	"job-search-service/internal/compensation"
+	"job-search-service/internal/config"
 	"job-search-service/internal/elastic"
 	grpcHandler "job-search-service/internal/grpc"
+	"job-search-service/internal/migration"
+	"job-search-service/internal/querylang"
 	"job-search-service/internal/repository"
 	"job-search-service/internal/service"
+	"job-search-service/internal/taxonomy"
 	pb "job-search-service/proto"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
-	"gopkg.in/yaml.v3"
 )
 
-type Config struct {
-	Elasticsearch struct {
-		URL   string `yaml:"url"`
-		Index string `yaml:"index"`
-	} `yaml:"elasticsearch"`
-	Server struct {
-		Port int `yaml:"port"`
-	} `yaml:"server"`
-}
+func main() {
+	log.Println("Starting Job Search Service...")
 
-func loadConfig() (*Config, error) {
-	data, err := os.ReadFile("configs/config.yaml")
+	cfg, err := config.Load(config.DefaultPath)
 	if err != nil {
-		return nil, fmt.Errorf("error reading config file: %w", err)
+		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("error parsing config file: %w", err)
+	skills, err := taxonomy.Load(cfg.Skills.Taxonomy)
+	if err != nil {
+		log.Fatalf("Failed to load skill taxonomy: %v", err)
 	}
 
-	return &config, nil
-}
+	ctx := context.Background()
+	var (
+		esClient *elastic.Client
+		jobRepo  repository.JobStore
+	)
+	switch cfg.Backend {
+	case config.BackendMemory:
+		log.Println("Using the in-memory job store; jobs are lost on shutdown")
+		jobRepo = repository.NewMemoryJobRepository(skills)
+	case config.BackendFile:
+		fileRepo, err := repository.OpenFileJobRepository(cfg.File.Path, skills)
+		if err != nil {
+			log.Fatalf("Failed to open job store: %v", err)
+		}
+		defer fileRepo.Close()
+		log.Printf("Using the file job store at %s", cfg.File.Path)
+		jobRepo = fileRepo
+	default:
+		esClient, err = elastic.NewClient(cfg.Elasticsearch.URL)
+		if err != nil {
+			log.Fatalf("Failed to create Elasticsearch client: %v", err)
+		}
 
-func main() {
-	log.Println("Starting Job Search Service...")
+		// The index analyzers refer to the synonyms set, so it has to exist
+		// before the index is created.
+		if err := esClient.PutSynonymsSet(ctx, elastic.SkillSynonymsSet, skills.SynonymRules()); err != nil {
+			log.Fatalf("Failed to update skill synonyms: %v", err)
+		}
 
-	config, err := loadConfig()
-	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		migrator := migration.NewMigrator(esClient, cfg.Elasticsearch.Index)
+		if err := migrator.Bootstrap(ctx); err != nil {
+			log.Fatalf("Failed to prepare index: %v", err)
+		}
+		jobRepo = repository.NewJobRepository(esClient.ES, cfg.Elasticsearch.Index)
 	}
 
-	esClient, err := elastic.NewClient(config.Elasticsearch.URL)
+	converter, err := compensation.NewConverter(
+		cfg.Compensation.BaseCurrency,
+		cfg.Compensation.ExchangeRates,
+		cfg.Compensation.HoursPerYear,
+	)
 	if err != nil {
-		log.Fatalf("Failed to create Elasticsearch client: %v", err)
+		log.Fatalf("Invalid compensation config: %v", err)
 	}
 
-	ctx := context.Background()
-	if err := esClient.CreateIndex(ctx, config.Elasticsearch.Index); err != nil {
-		log.Fatalf("Failed to create index: %v", err)
+	if err := cfg.Ranking.Validate(); err != nil {
+		log.Fatalf("Invalid ranking config: %v", err)
 	}
 
-	jobRepo := repository.NewJobRepository(esClient.ES, config.Elasticsearch.Index)
-	jobService := service.NewJobService(jobRepo)
-	jobHandler := grpcHandler.NewJobHandler(jobService)
+	jobService := service.NewJobService(jobRepo, converter, skills, querylang.Limits{
+		MaxLength: cfg.Search.MaxQueryLength,
+		MaxTerms:  cfg.Search.MaxClauses,
+	}, cfg.Ranking, service.BulkOptions{
+		BatchSize:     cfg.Bulk.BatchSize,
+		FlushInterval: cfg.Bulk.FlushInterval,
+	})
+	jobHandler := grpcHandler.NewJobHandler(jobService, cfg.Server.AdminToken)
 
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", config.Server.Port))
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Server.Port))
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
@@ -76,7 +105,7 @@ func main() {
 
 	reflection.Register(grpcServer)
 
-	log.Printf("gRPC server listening on port %d", config.Server.Port)
+	log.Printf("gRPC server listening on port %d", cfg.Server.Port)
 
 	go func() {
 		if err := grpcServer.Serve(lis); err != nil {
@@ -85,10 +114,33 @@ func main() {
 	}()
 
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	<-sigChan
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := <-sigChan; sig == syscall.SIGHUP; sig = <-sigChan {
+		reloadSkills(ctx, esClient, skills)
+	}
 
 	log.Println("Shutting down gracefully...")
 	grpcServer.GracefulStop()
 	log.Println("Server stopped")
 }
+
+// reloadSkills rereads the skill taxonomy. On Elasticsearch it is pushed to
+// the synonyms set, which applies to searches without a reindex; the other
+// backends read the taxonomy directly. Jobs written before the reload
+// keep the skill names they were stored with.
+func reloadSkills(ctx context.Context, esClient *elastic.Client, skills *taxonomy.Taxonomy) {
+	log.Println("Reloading skill taxonomy...")
+
+	if err := skills.Reload(); err != nil {
+		log.Printf("Failed to reload skill taxonomy: %v", err)
+		return
+	}
+	if esClient != nil {
+		if err := esClient.PutSynonymsSet(ctx, elastic.SkillSynonymsSet, skills.SynonymRules()); err != nil {
+			log.Printf("Failed to update skill synonyms: %v", err)
+			return
+		}
+	}
+
+	log.Println("Skill taxonomy reloaded")
+}
@@ -0,0 +1,116 @@
+package compensation
+
+import (
+	"errors"
+	"fmt"
+	"job-search-service/internal/models"
+	"strings"
+)
+
+// DefaultHoursPerYear converts hourly pay assuming a 40 hour week.
+const DefaultHoursPerYear = 2080
+
+var (
+	ErrUnknownCurrency    = errors.New("unknown currency")
+	ErrInvalidSalaryRange = errors.New("salary_min must not be greater than salary_max")
+)
+
+// Converter normalises salaries to a yearly amount in a base currency using a
+// fixed exchange-rate table, so that ranges given in different currencies and
+// pay periods can be compared by a single range query.
+type Converter struct {
+	base         string
+	rates        map[string]float64
+	hoursPerYear float64
+}
+
+// NewConverter takes rates as the value of one unit of each currency in the
+// base currency, e.g. {"USD": 0.92} for a EUR base.
+func NewConverter(base string, rates map[string]float64, hoursPerYear float64) (*Converter, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	if base == "" {
+		return nil, fmt.Errorf("base currency is required")
+	}
+	if hoursPerYear <= 0 {
+		hoursPerYear = DefaultHoursPerYear
+	}
+
+	normalized := make(map[string]float64, len(rates)+1)
+	for currency, rate := range rates {
+		if rate <= 0 {
+			return nil, fmt.Errorf("exchange rate for %s must be positive", currency)
+		}
+		normalized[strings.ToUpper(currency)] = rate
+	}
+	normalized[base] = 1
+
+	return &Converter{
+		base:         base,
+		rates:        normalized,
+		hoursPerYear: hoursPerYear,
+	}, nil
+}
+
+func (c *Converter) BaseCurrency() string {
+	return c.base
+}
+
+// Annual converts an amount paid per period in currency to a yearly amount in
+// the base currency. An empty currency means the base currency and an empty
+// period means yearly.
+func (c *Converter) Annual(amount float64, currency string, period models.PayPeriod) (float64, error) {
+	if currency == "" {
+		currency = c.base
+	}
+	rate, ok := c.rates[strings.ToUpper(currency)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+
+	switch period {
+	case models.PayPeriodHourly:
+		amount *= c.hoursPerYear
+	case models.PayPeriodMonthly:
+		amount *= 12
+	case models.PayPeriodYearly, "":
+	default:
+		return 0, fmt.Errorf("unsupported pay period %q", period)
+	}
+
+	return amount * rate, nil
+}
+
+// Normalize sets the annual salary range of a job. A job with only one bound
+// gets it for both; a job without a range falls back to its legacy salary.
+func (c *Converter) Normalize(job *models.Job) error {
+	job.AnnualSalaryMin, job.AnnualSalaryMax = 0, 0
+
+	low, high := job.SalaryMin, job.SalaryMax
+	if low > 0 && high > 0 && low > high {
+		return ErrInvalidSalaryRange
+	}
+	if low == 0 {
+		low = high
+	}
+	if high == 0 {
+		high = low
+	}
+	if low == 0 {
+		if job.Salary > 0 {
+			job.AnnualSalaryMin, job.AnnualSalaryMax = job.Salary, job.Salary
+		}
+		return nil
+	}
+
+	annualMin, err := c.Annual(low, job.Currency, job.PayPeriod)
+	if err != nil {
+		return err
+	}
+	annualMax, err := c.Annual(high, job.Currency, job.PayPeriod)
+	if err != nil {
+		return err
+	}
+	job.AnnualSalaryMin, job.AnnualSalaryMax = annualMin, annualMax
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"job-search-service/internal/ranking"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const DefaultPath = "configs/config.yaml"
+
+// Backends a job store can be selected from.
+const (
+	BackendElasticsearch = "elasticsearch"
+	BackendMemory        = "memory"
+	BackendFile          = "file"
+)
+
+// DefaultFilePath is where the file backend stores jobs by default.
+const DefaultFilePath = "data/jobs.sqlite"
+
+type Config struct {
+	// Backend selects where jobs are stored; empty means elasticsearch.
+	Backend       string `yaml:"backend"`
+	Elasticsearch struct {
+		URL   string `yaml:"url"`
+		Index string `yaml:"index"`
+	} `yaml:"elasticsearch"`
+	File struct {
+		// Path is the SQLite file jobs and their full-text index are stored
+		// in.
+		Path string `yaml:"path"`
+	} `yaml:"file"`
+	Server struct {
+		Port int `yaml:"port"`
+		// AdminToken unlocks debugging options such as search explanations.
+		// Empty disables them.
+		AdminToken string `yaml:"admin_token"`
+	} `yaml:"server"`
+	Compensation struct {
+		BaseCurrency string `yaml:"base_currency"`
+		// ExchangeRates holds the value of one unit of each currency in the
+		// base currency.
+		ExchangeRates map[string]float64 `yaml:"exchange_rates"`
+		HoursPerYear  float64            `yaml:"hours_per_year"`
+	} `yaml:"compensation"`
+	Search struct {
+		// MaxQueryLength is the maximum number of characters of a search
+		// query or suggestion prefix.
+		MaxQueryLength int `yaml:"max_query_length"`
+		// MaxClauses limits the terms of a search query and the number of
+		// skills filtered on.
+		MaxClauses int `yaml:"max_clauses"`
+	} `yaml:"search"`
+	Bulk struct {
+		// BatchSize is the number of jobs per write of BulkCreateJobs.
+		BatchSize int `yaml:"batch_size"`
+		// FlushInterval is the longest a received job waits for its batch
+		// to fill up, e.g. "1s".
+		FlushInterval time.Duration `yaml:"flush_interval"`
+	} `yaml:"bulk"`
+	// Ranking holds the ranking profiles clients can choose per search.
+	Ranking ranking.Config `yaml:"ranking"`
+	Skills  struct {
+		// Taxonomy is the path of the YAML file with canonical skill names
+		// and their aliases.
+		Taxonomy string `yaml:"taxonomy"`
+	} `yaml:"skills"`
+}
+
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("error parsing config file: %w", err)
+	}
+
+	switch config.Backend {
+	case "":
+		config.Backend = BackendElasticsearch
+	case BackendElasticsearch, BackendMemory, BackendFile:
+	default:
+		return nil, fmt.Errorf("unknown backend %q, expected %s, %s or %s",
+			config.Backend, BackendElasticsearch, BackendMemory, BackendFile)
+	}
+	if config.File.Path == "" {
+		config.File.Path = DefaultFilePath
+	}
+
+	return &config, nil
+}
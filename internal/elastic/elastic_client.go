@@ -1,9 +1,12 @@
 package elastic
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
 
 	"github.com/elastic/go-elasticsearch/v8"
 )
@@ -46,12 +49,21 @@ func (c *Client) CreateIndex(ctx context.Context, indexName string) error {
 
 	if res.StatusCode == 200 {
 		log.Printf("Index '%s' already exists", indexName)
+
+		version, err := c.MappingVersion(ctx, indexName)
+		if err != nil {
+			return err
+		}
+		if version != MappingVersion {
+			log.Printf("Warning: index '%s' has mapping version %d, expected %d", indexName, version, MappingVersion)
+		}
 		return nil
 	}
 
 	res, err = c.ES.Indices.Create(
 		indexName,
 		c.ES.Indices.Create.WithContext(ctx),
+		c.ES.Indices.Create.WithBody(bytes.NewReader(indexMapping)),
 	)
 	if err != nil {
 		return fmt.Errorf("error creating index: %w", err)
@@ -62,6 +74,119 @@ func (c *Client) CreateIndex(ctx context.Context, indexName string) error {
 		return fmt.Errorf("error creating index: %s", res.String())
 	}
 
-	log.Printf("Index '%s' created successfully", indexName)
+	log.Printf("Index '%s' created successfully (mapping version %d)", indexName, MappingVersion)
+	return nil
+}
+
+// MappingVersion returns the _meta.version stored in the mapping of the given
+// index, or 0 if the index was created without an explicit mapping.
+func (c *Client) MappingVersion(ctx context.Context, indexName string) (int, error) {
+	res, err := c.ES.Indices.GetMapping(
+		c.ES.Indices.GetMapping.WithContext(ctx),
+		c.ES.Indices.GetMapping.WithIndex(indexName),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error getting index mapping: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, fmt.Errorf("error getting index mapping: %s", res.String())
+	}
+
+	var result map[string]struct {
+		Mappings struct {
+			Meta struct {
+				Version int `json:"version"`
+			} `json:"_meta"`
+		} `json:"mappings"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("error parsing index mapping: %w", err)
+	}
+
+	for _, index := range result {
+		return index.Mappings.Meta.Version, nil
+	}
+	return 0, nil
+}
+
+func (c *Client) IndexExists(ctx context.Context, indexName string) (bool, error) {
+	res, err := c.ES.Indices.Exists(
+		[]string{indexName},
+		c.ES.Indices.Exists.WithContext(ctx),
+	)
+	if err != nil {
+		return false, fmt.Errorf("error checking if index exists: %w", err)
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case 200:
+		return true, nil
+	case 404:
+		return false, nil
+	default:
+		return false, fmt.Errorf("error checking if index exists: %s", res.String())
+	}
+}
+
+func (c *Client) DeleteIndex(ctx context.Context, indexName string) error {
+	res, err := c.ES.Indices.Delete(
+		[]string{indexName},
+		c.ES.Indices.Delete.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("error deleting index: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != 404 {
+		return fmt.Errorf("error deleting index: %s", res.String())
+	}
+
+	log.Printf("Index '%s' deleted", indexName)
+	return nil
+}
+
+// PutSynonymsSet creates or replaces a synonyms set. Elasticsearch reloads the
+// search analyzers using the set, so updates apply without reindexing.
+func (c *Client) PutSynonymsSet(ctx context.Context, id string, rules map[string]string) error {
+	ruleIDs := make([]string, 0, len(rules))
+	for ruleID := range rules {
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	sort.Strings(ruleIDs)
+
+	set := make([]interface{}, 0, len(rules))
+	for _, ruleID := range ruleIDs {
+		set = append(set, map[string]interface{}{
+			"id":       ruleID,
+			"synonyms": rules[ruleID],
+		})
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"synonyms_set": set,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding synonyms set: %w", err)
+	}
+
+	res, err := c.ES.SynonymsPutSynonym(
+		id,
+		bytes.NewReader(body),
+		c.ES.SynonymsPutSynonym.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("error updating synonyms set: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("error updating synonyms set: %s", res.String())
+	}
+
+	log.Printf("Synonyms set '%s' updated with %d rules", id, len(rules))
 	return nil
 }
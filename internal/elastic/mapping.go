@@ -0,0 +1,37 @@
+package elastic
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+// indexMapping holds the settings and mappings applied when the jobs index is
+// created. Bump _meta.version whenever the mapping changes.
+//
+//go:embed mapping.json
+var indexMapping []byte
+
+// SkillSynonymsSet is the synonyms set used by the skill_synonyms filter in
+// mapping.json. It has to exist before an index is created.
+const SkillSynonymsSet = "job-skills"
+
+// MappingVersion is the _meta.version of the embedded mapping.
+var MappingVersion = mustParseMappingVersion(indexMapping)
+
+func mustParseMappingVersion(data []byte) int {
+	var mapping struct {
+		Mappings struct {
+			Meta struct {
+				Version int `json:"version"`
+			} `json:"_meta"`
+		} `json:"mappings"`
+	}
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		panic(fmt.Sprintf("invalid embedded index mapping: %v", err))
+	}
+	if mapping.Mappings.Meta.Version < 1 {
+		panic("embedded index mapping has no _meta.version")
+	}
+	return mapping.Mappings.Meta.Version
+}
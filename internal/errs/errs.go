@@ -0,0 +1,90 @@
+package errs
+
+// Kind classifies an error so that the transport layer can pick a status
+// code without knowing where the error came from.
+type Kind int
+
+const (
+	// Internal is the kind of errors that are not an *Error.
+	Internal Kind = iota
+	NotFound
+	InvalidArgument
+	// Conflict means the request lost a race with another write.
+	Conflict
+	// Unavailable means a dependency could not be reached; the request may
+	// succeed when retried.
+	Unavailable
+)
+
+func (k Kind) String() string {
+	switch k {
+	case NotFound:
+		return "not found"
+	case InvalidArgument:
+		return "invalid argument"
+	case Conflict:
+		return "conflict"
+	case Unavailable:
+		return "unavailable"
+	default:
+		return "internal"
+	}
+}
+
+// Error is a domain error of a known kind.
+type Error struct {
+	Kind Kind
+	// Reason is a stable UPPER_SNAKE_CASE identifier of the cause, such as
+	// JOB_NOT_FOUND, that clients can branch on.
+	Reason string
+	// Field is the request field an InvalidArgument error is about, using
+	// the proto field names.
+	Field string
+	Msg   string
+	// Violations lists the invalid fields of an InvalidArgument error that
+	// concerns more than one field.
+	Violations []Violation
+	// Metadata holds details such as the ID of the job concerned.
+	Metadata map[string]string
+	Err      error
+}
+
+// Violation describes why a request field is invalid.
+type Violation struct {
+	Field       string
+	Description string
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Err == nil:
+		return e.Msg
+	case e.Msg == "":
+		return e.Err.Error()
+	default:
+		return e.Msg + ": " + e.Err.Error()
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports errors of the same kind and reason as equal, so that an *Error
+// declared as a sentinel matches its copies with errors.Is.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind && t.Reason == e.Reason
+}
+
+// WithField returns a copy of e about field.
+func (e *Error) WithField(field string) *Error {
+	copied := *e
+	copied.Field = field
+	return &copied
+}
+
+// Wrap returns an error of the given kind and reason wrapping err.
+func Wrap(kind Kind, reason, field string, err error) *Error {
+	return &Error{Kind: kind, Reason: reason, Field: field, Err: err}
+}
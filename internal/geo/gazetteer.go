@@ -0,0 +1,89 @@
+package geo
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"job-search-service/internal/models"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// cities.csv is a small offline gazetteer of common city names, so that jobs
+// can be geocoded at ingest without calling an external service.
+//
+//go:embed cities.csv
+var citiesCSV []byte
+
+var cities = mustParseCities(citiesCSV)
+
+func mustParseCities(data []byte) map[string]models.GeoPoint {
+	cities := make(map[string]models.GeoPoint)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, ",")
+		if len(fields) < 4 {
+			panic(fmt.Sprintf("cities.csv:%d: expected at least 4 fields", line))
+		}
+		lat, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			panic(fmt.Sprintf("cities.csv:%d: invalid latitude: %v", line, err))
+		}
+		lon, err := strconv.ParseFloat(fields[3], 64)
+		if err != nil {
+			panic(fmt.Sprintf("cities.csv:%d: invalid longitude: %v", line, err))
+		}
+
+		point := models.GeoPoint{Lat: lat, Lon: lon}
+		cities[normalize(fields[0])] = point
+		for _, alias := range fields[4:] {
+			cities[normalize(alias)] = point
+		}
+	}
+
+	return cities
+}
+
+// Lookup geocodes a free-text location such as "Berlin" or "Berlin, Germany".
+// The whole string is tried first, then each comma separated part.
+func Lookup(location string) (models.GeoPoint, bool) {
+	if point, ok := cities[normalize(location)]; ok {
+		return point, true
+	}
+
+	for _, part := range strings.Split(location, ",") {
+		if point, ok := cities[normalize(part)]; ok {
+			return point, true
+		}
+	}
+
+	return models.GeoPoint{}, false
+}
+
+func normalize(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+const earthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle distance between two points.
+func DistanceKm(a, b models.GeoPoint) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := (b.Lat - a.Lat) * math.Pi / 180
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func Valid(point models.GeoPoint) bool {
+	return point.Lat >= -90 && point.Lat <= 90 && point.Lon >= -180 && point.Lon <= 180
+}
@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// adminTokenHeader is the metadata key carrying the admin token.
+const adminTokenHeader = "x-admin-token"
+
+// isAdmin reports whether the request carries the configured admin token.
+func (h *JobHandler) isAdmin(ctx context.Context) bool {
+	if h.adminToken == "" {
+		return false
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, token := range md.Get(adminTokenHeader) {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) == 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"job-search-service/internal/errs"
+	"job-search-service/internal/validation"
+	pb "job-search-service/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the ErrorInfo domain of the reasons defined by this service.
+const errorDomain = "job-search-service"
+
+var statusCodes = map[errs.Kind]codes.Code{
+	errs.NotFound:        codes.NotFound,
+	errs.InvalidArgument: codes.InvalidArgument,
+	// Conflicts are lost optimistic concurrency checks, so clients should
+	// re-read the job before retrying.
+	errs.Conflict:    codes.Aborted,
+	errs.Unavailable: codes.Unavailable,
+}
+
+// toStatus maps an error from the service to a gRPC status. Domain errors
+// carry a google.rpc.ErrorInfo with their reason, and invalid arguments a
+// google.rpc.BadRequest naming the field. Errors of unknown kind become
+// INTERNAL without their message, which is only logged.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+
+	var domainErr *errs.Error
+	if !errors.As(err, &domainErr) {
+		return status.Error(codes.Internal, "internal error")
+	}
+	code, ok := statusCodes[domainErr.Kind]
+	if !ok {
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	st := status.New(code, err.Error())
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   domainErr.Reason,
+		Domain:   errorDomain,
+		Metadata: domainErr.Metadata,
+	}}
+	if domainErr.Kind == errs.InvalidArgument {
+		violations := append([]errs.Violation{}, domainErr.Violations...)
+		if domainErr.Field != "" {
+			violations = append(violations, errs.Violation{Field: domainErr.Field, Description: domainErr.Error()})
+		}
+		if len(violations) > 0 {
+			details = append(details, badRequest(violations))
+		}
+	}
+
+	withDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// toBulkError describes the failure of one job of BulkCreateJobs with the
+// status CreateJob would have returned.
+func toBulkError(err error) *pb.BulkCreateJobError {
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.Convert(toStatus(err))
+	}
+
+	bulkErr := &pb.BulkCreateJobError{
+		Code:    int32(st.Code()),
+		Message: st.Message(),
+	}
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			bulkErr.Reason = detail.Reason
+		case *errdetails.BadRequest:
+			for _, violation := range detail.FieldViolations {
+				bulkErr.FieldViolations = append(bulkErr.FieldViolations, &pb.FieldViolation{
+					Field:       violation.Field,
+					Description: violation.Description,
+				})
+			}
+		}
+	}
+	return bulkErr
+}
+
+func badRequest(violations []errs.Violation) *errdetails.BadRequest {
+	fieldViolations := make([]*errdetails.BadRequest_FieldViolation, 0, len(violations))
+	for _, violation := range violations {
+		fieldViolations = append(fieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Description,
+		})
+	}
+	return &errdetails.BadRequest{FieldViolations: fieldViolations}
+}
+
+// invalidArgument reports an invalid request field with the same details as
+// the validation rules of the service.
+func invalidArgument(field, format string, args ...interface{}) error {
+	return toStatus(validation.FieldError(field, format, args...))
+}
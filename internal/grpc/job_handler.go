@@ -2,71 +2,281 @@ package grpc
 
 import (
 	"context"
+	"io"
+	"job-search-service/internal/models"
 	"job-search-service/internal/service"
 	pb "job-search-service/proto"
 	"log"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type JobHandler struct {
 	pb.UnimplementedJobServiceServer
-	service *service.JobService
+	service    *service.JobService
+	adminToken string
 }
 
-func NewJobHandler(service *service.JobService) *JobHandler {
+// NewJobHandler creates the handler. Admin-only options are rejected if
+// adminToken is empty.
+func NewJobHandler(service *service.JobService, adminToken string) *JobHandler {
 	return &JobHandler{
-		service: service,
+		service:    service,
+		adminToken: adminToken,
 	}
 }
 
 func (h *JobHandler) CreateJob(ctx context.Context, req *pb.CreateJobRequest) (*pb.CreateJobResponse, error) {
 	log.Printf("Creating job: %s", req.Title)
 
-	id, err := h.service.CreateJob(
-		ctx,
-		req.Title,
-		req.Description,
-		req.Company,
-		req.Location,
-		req.Skills,
-		req.Salary,
-	)
+	job, err := fromCreateJobRequest(req)
 	if err != nil {
-		log.Printf("Error creating job: %v", err)
 		return nil, err
 	}
 
+	id, err := h.service.CreateJob(ctx, job)
+	if err != nil {
+		log.Printf("Error creating job: %v", err)
+		return nil, toStatus(err)
+	}
+
 	return &pb.CreateJobResponse{
 		Id:      id,
 		Message: "Job created successfully",
 	}, nil
 }
 
+// BulkCreateJobs creates the jobs of a client stream in batches and replies
+// with one result per job when the client closes the stream.
+func (h *JobHandler) BulkCreateJobs(stream pb.JobService_BulkCreateJobsServer) error {
+	log.Println("Bulk creating jobs")
+
+	bulk := h.service.BulkCreate(stream.Context())
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Usually the client went away and the stream context, which
+			// the writes use, is cancelled. Full batches have been written
+			// already; the client cannot learn their IDs, so the rest is
+			// dropped rather than adding more jobs it will send again.
+			bulk.Abort()
+			log.Printf("Error receiving jobs: %v", err)
+			return err
+		}
+
+		job, err := fromCreateJobRequest(req)
+		if err != nil {
+			bulk.Fail(err)
+			continue
+		}
+		bulk.Add(job)
+	}
+
+	// A failed refresh does not fail the import: the jobs are stored and
+	// a retry would create them again under new IDs.
+	results, err := bulk.Close()
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	res := &pb.BulkCreateJobsResponse{
+		Results: make([]*pb.BulkCreateJobResult, 0, len(results)),
+	}
+	for i, result := range results {
+		item := &pb.BulkCreateJobResult{Index: int32(i), Id: result.ID}
+		if result.Err != nil {
+			log.Printf("Error creating job %d: %v", i, result.Err)
+			item.Error = toBulkError(result.Err)
+			res.FailedCount++
+		} else {
+			res.CreatedCount++
+		}
+		res.Results = append(res.Results, item)
+	}
+	log.Printf("Bulk created %d of %d jobs", res.CreatedCount, len(results))
+
+	return stream.SendAndClose(res)
+}
+
+func fromCreateJobRequest(req *pb.CreateJobRequest) (*models.Job, error) {
+	remote, err := jobRemotePolicy(req.WorkMode, req.RemotePolicy)
+	if err != nil {
+		return nil, err
+	}
+	currency, err := jobCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Job{
+		Title:          req.Title,
+		Description:    req.Description,
+		Company:        req.Company,
+		Location:       req.Location,
+		Skills:         req.Skills,
+		Salary:         req.Salary,
+		Geo:            fromProtoGeoPoint(req.Geo),
+		WorkMode:       workModes[req.WorkMode],
+		Remote:         remote,
+		SalaryMin:      req.SalaryMin,
+		SalaryMax:      req.SalaryMax,
+		Currency:       currency,
+		PayPeriod:      payPeriods[req.PayPeriod],
+		EmploymentType: employmentTypes[req.EmploymentType],
+		Seniority:      seniorities[req.Seniority],
+		Featured:       req.Featured,
+	}, nil
+}
+
 func (h *JobHandler) SearchJobs(ctx context.Context, req *pb.SearchJobsRequest) (*pb.SearchJobsResponse, error) {
 	log.Printf("Searching jobs with query: %s", req.Query)
 
-	jobs, err := h.service.SearchJobs(ctx, req.Query, req.Location, req.Skills)
+	params := models.SearchParams{
+		Query:              req.Query,
+		Location:           req.Location,
+		Skills:             req.Skills,
+		SkillsMatch:        skillsMatchModes[req.SkillsMatchMode],
+		SkillsMinimumMatch: int(req.SkillsMinimumMatch),
+		MinSalary:          req.MinSalary,
+		MaxSalary:          req.MaxSalary,
+		PageSize:           int(req.PageSize),
+		PageToken:          req.PageToken,
+		Sort:               toSortOptions(req.Sort),
+		AutoCorrect:        req.AutoCorrect,
+		RankingProfile:     req.RankingProfile,
+		Explain:            req.Explain,
+	}
+	if req.Explain && !h.isAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "explain requires the admin token")
+	}
+	if near := req.GeoDistance; near != nil {
+		params.Near = &models.GeoDistance{
+			Origin:         fromProtoGeoPoint(near.Origin),
+			OriginLocation: near.OriginLocation,
+			RadiusKm:       near.RadiusKm,
+		}
+	}
+	for _, mode := range req.WorkModes {
+		if workMode, ok := workModes[mode]; ok {
+			params.WorkModes = append(params.WorkModes, workMode)
+		}
+	}
+	for _, employmentType := range req.EmploymentTypes {
+		if value, ok := employmentTypes[employmentType]; ok {
+			params.EmploymentTypes = append(params.EmploymentTypes, value)
+		}
+	}
+	for _, seniority := range req.Seniorities {
+		if value, ok := seniorities[seniority]; ok {
+			params.Seniorities = append(params.Seniorities, value)
+		}
+	}
+	if req.SalaryCurrency != "" {
+		currency, ok := currencyCode(req.SalaryCurrency)
+		if !ok {
+			return nil, invalidArgument("salary_currency", "must be an ISO 4217 code")
+		}
+		params.SalaryCurrency = currency
+	}
+	params.SalaryPeriod = payPeriods[req.SalaryPeriod]
+	if req.RemoteCountry != "" {
+		country, ok := countryCode(req.RemoteCountry)
+		if !ok {
+			return nil, invalidArgument("remote_country", "must be an ISO 3166-1 alpha-2 code")
+		}
+		params.RemoteCountry = country
+	}
+	if req.RemoteUtcOffsetMinutes != nil {
+		offset := int(*req.RemoteUtcOffsetMinutes)
+		if !validUTCOffset(offset) {
+			return nil, invalidArgument("remote_utc_offset_minutes", "must be between -720 and 840")
+		}
+		params.RemoteUTCOffsetMinutes = &offset
+	}
+	if highlight := req.Highlight; highlight != nil {
+		params.Highlight = &models.HighlightOptions{
+			PreTag:            highlight.PreTag,
+			PostTag:           highlight.PostTag,
+			FragmentSize:      int(highlight.FragmentSize),
+			NumberOfFragments: int(highlight.NumberOfFragments),
+		}
+	}
+	for _, facet := range req.Facets {
+		field, ok := facetFields[facet.Field]
+		if !ok {
+			return nil, invalidArgument("facets.field", "%v is not supported", facet.Field)
+		}
+		if facet.CalendarInterval != "" && !calendarIntervals[facet.CalendarInterval] {
+			return nil, invalidArgument("facets.calendar_interval", "%q is not supported", facet.CalendarInterval)
+		}
+		params.Facets = append(params.Facets, models.FacetRequest{
+			Field:            field,
+			Size:             int(facet.Size),
+			Interval:         facet.Interval,
+			CalendarInterval: facet.CalendarInterval,
+		})
+	}
+	if req.PostedAfter != nil {
+		params.PostedAfter = req.PostedAfter.AsTime()
+	}
+	if req.PostedBefore != nil {
+		params.PostedBefore = req.PostedBefore.AsTime()
+	}
+
+	result, err := h.service.SearchJobs(ctx, params)
 	if err != nil {
 		log.Printf("Error searching jobs: %v", err)
-		return nil, err
+		return nil, toStatus(err)
 	}
 
-	pbJobs := make([]*pb.Job, 0, len(jobs))
-	for _, job := range jobs {
-		pbJobs = append(pbJobs, &pb.Job{
-			Id:          job.ID,
-			Title:       job.Title,
-			Description: job.Description,
-			Company:     job.Company,
-			Location:    job.Location,
-			Skills:      job.Skills,
-			Salary:      job.Salary,
-			CreatedAt:   job.CreatedAt.Format("2006-01-02"),
-		})
+	pbJobs := make([]*pb.Job, 0, len(result.Jobs))
+	for _, job := range result.Jobs {
+		pbJobs = append(pbJobs, toProtoJob(job))
 	}
 
 	return &pb.SearchJobsResponse{
-		Jobs:  pbJobs,
-		Total: int32(len(pbJobs)),
+		Jobs:           pbJobs,
+		Total:          result.Total,
+		NextPageToken:  result.NextPageToken,
+		Facets:         toProtoFacets(result.Facets),
+		SuggestedQuery: result.SuggestedQuery,
+		QueryCorrected: result.QueryCorrected,
+	}, nil
+}
+
+var suggestFields = map[pb.SuggestField]models.SuggestField{
+	pb.SuggestField_SUGGEST_FIELD_TITLE:   models.SuggestTitle,
+	pb.SuggestField_SUGGEST_FIELD_COMPANY: models.SuggestCompany,
+	pb.SuggestField_SUGGEST_FIELD_SKILL:   models.SuggestSkill,
+}
+
+func (h *JobHandler) SuggestJobs(ctx context.Context, req *pb.SuggestJobsRequest) (*pb.SuggestJobsResponse, error) {
+	field, ok := suggestFields[req.Field]
+	if !ok {
+		return nil, invalidArgument("field", "%v is not supported", req.Field)
+	}
+
+	suggestions, err := h.service.SuggestJobs(ctx, req.Prefix, field, int(req.Size))
+	if err != nil {
+		log.Printf("Error suggesting jobs: %v", err)
+		return nil, toStatus(err)
+	}
+
+	pbSuggestions := make([]*pb.Suggestion, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		pbSuggestions = append(pbSuggestions, &pb.Suggestion{
+			Text:  suggestion.Text,
+			Score: suggestion.Score,
+		})
+	}
+
+	return &pb.SuggestJobsResponse{
+		Suggestions: pbSuggestions,
 	}, nil
 }
 
@@ -76,33 +286,406 @@ func (h *JobHandler) GetJob(ctx context.Context, req *pb.GetJobRequest) (*pb.Get
 	job, err := h.service.GetJob(ctx, req.Id)
 	if err != nil {
 		log.Printf("Error getting job: %v", err)
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	return &pb.GetJobResponse{
-		Job: &pb.Job{
-			Id:          job.ID,
-			Title:       job.Title,
-			Description: job.Description,
-			Company:     job.Company,
-			Location:    job.Location,
-			Skills:      job.Skills,
-			Salary:      job.Salary,
-			CreatedAt:   job.CreatedAt.Format("2006-01-02"),
-		},
+		Job: toProtoJob(job),
 	}, nil
 }
 
 func (h *JobHandler) DeleteJob(ctx context.Context, req *pb.DeleteJobRequest) (*pb.DeleteJobResponse, error) {
 	log.Printf("Deleting job with ID: %s", req.Id)
 
-	err := h.service.DeleteJob(ctx, req.Id)
+	err := h.service.DeleteJob(ctx, req.Id, req.Version)
 	if err != nil {
 		log.Printf("Error deleting job: %v", err)
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	return &pb.DeleteJobResponse{
 		Message: "Job deleted successfully",
 	}, nil
 }
+
+func (h *JobHandler) UpdateJob(ctx context.Context, req *pb.UpdateJobRequest) (*pb.UpdateJobResponse, error) {
+	log.Printf("Updating job with ID: %s", req.Id)
+
+	remote, err := jobRemotePolicy(req.WorkMode, req.RemotePolicy)
+	if err != nil {
+		return nil, err
+	}
+	currency, err := jobCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := h.service.UpdateJob(ctx, req.Id, req.Version, &models.Job{
+		Title:          req.Title,
+		Description:    req.Description,
+		Company:        req.Company,
+		Location:       req.Location,
+		Skills:         req.Skills,
+		Salary:         req.Salary,
+		Geo:            fromProtoGeoPoint(req.Geo),
+		WorkMode:       workModes[req.WorkMode],
+		Remote:         remote,
+		SalaryMin:      req.SalaryMin,
+		SalaryMax:      req.SalaryMax,
+		Currency:       currency,
+		PayPeriod:      payPeriods[req.PayPeriod],
+		EmploymentType: employmentTypes[req.EmploymentType],
+		Seniority:      seniorities[req.Seniority],
+		Featured:       req.Featured,
+	})
+	if err != nil {
+		log.Printf("Error updating job: %v", err)
+		return nil, toStatus(err)
+	}
+
+	return &pb.UpdateJobResponse{
+		Job:     toProtoJob(job),
+		Message: "Job updated successfully",
+	}, nil
+}
+
+func (h *JobHandler) PatchJob(ctx context.Context, req *pb.PatchJobRequest) (*pb.PatchJobResponse, error) {
+	log.Printf("Patching job with ID: %s (fields: %v)", req.Id, req.GetUpdateMask().GetPaths())
+
+	patch := req.GetJob()
+	// Whether the policy applies depends on the stored work mode when the
+	// mask does not include work_mode, so the service drops it if not.
+	remote, err := fromProtoRemotePolicy(patch.GetRemotePolicy())
+	if err != nil {
+		return nil, err
+	}
+	currency, err := jobCurrency(patch.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := h.service.PatchJob(
+		ctx,
+		req.Id,
+		req.Version,
+		&models.Job{
+			Title:          patch.GetTitle(),
+			Description:    patch.GetDescription(),
+			Company:        patch.GetCompany(),
+			Location:       patch.GetLocation(),
+			Skills:         patch.GetSkills(),
+			Salary:         patch.GetSalary(),
+			Geo:            fromProtoGeoPoint(patch.GetGeo()),
+			WorkMode:       workModes[patch.GetWorkMode()],
+			Remote:         remote,
+			SalaryMin:      patch.GetSalaryMin(),
+			SalaryMax:      patch.GetSalaryMax(),
+			Currency:       currency,
+			PayPeriod:      payPeriods[patch.GetPayPeriod()],
+			EmploymentType: employmentTypes[patch.GetEmploymentType()],
+			Seniority:      seniorities[patch.GetSeniority()],
+			Featured:       patch.GetFeatured(),
+		},
+		req.GetUpdateMask().GetPaths(),
+	)
+	if err != nil {
+		log.Printf("Error patching job: %v", err)
+		return nil, toStatus(err)
+	}
+
+	return &pb.PatchJobResponse{
+		Job:     toProtoJob(job),
+		Message: "Job patched successfully",
+	}, nil
+}
+
+var sortFields = map[pb.SortField]models.SortField{
+	pb.SortField_SORT_FIELD_RELEVANCE:  models.SortByRelevance,
+	pb.SortField_SORT_FIELD_CREATED_AT: models.SortByCreatedAt,
+	pb.SortField_SORT_FIELD_SALARY:     models.SortBySalary,
+	pb.SortField_SORT_FIELD_TITLE:      models.SortByTitle,
+	pb.SortField_SORT_FIELD_COMPANY:    models.SortByCompany,
+	pb.SortField_SORT_FIELD_DISTANCE:   models.SortByDistance,
+}
+
+func toSortOptions(sort []*pb.SortOption) []models.SortOption {
+	options := make([]models.SortOption, 0, len(sort))
+	for _, option := range sort {
+		field, ok := sortFields[option.Field]
+		if !ok {
+			continue
+		}
+
+		descending := false
+		switch option.Order {
+		case pb.SortOrder_SORT_ORDER_DESC:
+			descending = true
+		case pb.SortOrder_SORT_ORDER_DEFAULT:
+			// Distance defaults to nearest first.
+			descending = field == models.SortByRelevance || field == models.SortByCreatedAt || field == models.SortBySalary
+		}
+
+		options = append(options, models.SortOption{
+			Field:      field,
+			Descending: descending,
+		})
+	}
+	return options
+}
+
+var facetFields = map[pb.FacetField]models.FacetField{
+	pb.FacetField_FACET_FIELD_SKILLS:          models.FacetSkills,
+	pb.FacetField_FACET_FIELD_LOCATION:        models.FacetLocation,
+	pb.FacetField_FACET_FIELD_COMPANY:         models.FacetCompany,
+	pb.FacetField_FACET_FIELD_SALARY:          models.FacetSalary,
+	pb.FacetField_FACET_FIELD_CREATED_AT:      models.FacetCreatedAt,
+	pb.FacetField_FACET_FIELD_EMPLOYMENT_TYPE: models.FacetEmploymentType,
+	pb.FacetField_FACET_FIELD_SENIORITY:       models.FacetSeniority,
+}
+
+var calendarIntervals = map[string]bool{
+	"day":     true,
+	"week":    true,
+	"month":   true,
+	"quarter": true,
+	"year":    true,
+}
+
+func toProtoFacets(facets map[models.FacetField][]models.FacetBucket) map[string]*pb.FacetResult {
+	if len(facets) == 0 {
+		return nil
+	}
+
+	result := make(map[string]*pb.FacetResult, len(facets))
+	for field, buckets := range facets {
+		pbBuckets := make([]*pb.FacetBucket, 0, len(buckets))
+		for _, bucket := range buckets {
+			pbBuckets = append(pbBuckets, &pb.FacetBucket{
+				Key:   bucket.Key,
+				Count: bucket.Count,
+			})
+		}
+		result[string(field)] = &pb.FacetResult{Buckets: pbBuckets}
+	}
+	return result
+}
+
+func toProtoJob(job *models.Job) *pb.Job {
+	return &pb.Job{
+		Id:              job.ID,
+		Title:           job.Title,
+		Description:     job.Description,
+		Company:         job.Company,
+		Location:        job.Location,
+		Skills:          job.Skills,
+		Salary:          job.Salary,
+		CreatedAt:       job.CreatedAt.Format("2006-01-02"),
+		UpdatedAt:       job.UpdatedAt.Format("2006-01-02"),
+		Version:         job.Version,
+		Highlights:      toProtoHighlights(job.Highlights),
+		Geo:             toProtoGeoPoint(job.Geo),
+		DistanceKm:      job.DistanceKm,
+		WorkMode:        toProtoEnum(workModes, job.WorkMode),
+		RemotePolicy:    toProtoRemotePolicy(job.Remote),
+		SalaryMin:       job.SalaryMin,
+		SalaryMax:       job.SalaryMax,
+		Currency:        job.Currency,
+		PayPeriod:       toProtoEnum(payPeriods, job.PayPeriod),
+		EmploymentType:  toProtoEnum(employmentTypes, job.EmploymentType),
+		Seniority:       toProtoEnum(seniorities, job.Seniority),
+		AnnualSalaryMin: job.AnnualSalaryMin,
+		AnnualSalaryMax: job.AnnualSalaryMax,
+		MatchedSkills:   job.MatchedSkills,
+		Featured:        job.Featured,
+		Score:           job.Score,
+		Explanation:     toProtoExplanation(job.Explanation),
+	}
+}
+
+var workModes = map[pb.WorkMode]models.WorkMode{
+	pb.WorkMode_WORK_MODE_ONSITE: models.WorkModeOnsite,
+	pb.WorkMode_WORK_MODE_HYBRID: models.WorkModeHybrid,
+	pb.WorkMode_WORK_MODE_REMOTE: models.WorkModeRemote,
+}
+
+var skillsMatchModes = map[pb.SkillsMatchMode]models.SkillsMatchMode{
+	pb.SkillsMatchMode_SKILLS_MATCH_MODE_ANY:        models.SkillsMatchAny,
+	pb.SkillsMatchMode_SKILLS_MATCH_MODE_ALL:        models.SkillsMatchAll,
+	pb.SkillsMatchMode_SKILLS_MATCH_MODE_AT_LEAST_N: models.SkillsMatchAtLeast,
+}
+
+var payPeriods = map[pb.PayPeriod]models.PayPeriod{
+	pb.PayPeriod_PAY_PERIOD_HOURLY:  models.PayPeriodHourly,
+	pb.PayPeriod_PAY_PERIOD_MONTHLY: models.PayPeriodMonthly,
+	pb.PayPeriod_PAY_PERIOD_YEARLY:  models.PayPeriodYearly,
+}
+
+var employmentTypes = map[pb.EmploymentType]models.EmploymentType{
+	pb.EmploymentType_EMPLOYMENT_TYPE_FULL_TIME:  models.EmploymentFullTime,
+	pb.EmploymentType_EMPLOYMENT_TYPE_PART_TIME:  models.EmploymentPartTime,
+	pb.EmploymentType_EMPLOYMENT_TYPE_CONTRACT:   models.EmploymentContract,
+	pb.EmploymentType_EMPLOYMENT_TYPE_INTERNSHIP: models.EmploymentInternship,
+}
+
+var seniorities = map[pb.Seniority]models.Seniority{
+	pb.Seniority_SENIORITY_JUNIOR:    models.SeniorityJunior,
+	pb.Seniority_SENIORITY_MID:       models.SeniorityMid,
+	pb.Seniority_SENIORITY_SENIOR:    models.SenioritySenior,
+	pb.Seniority_SENIORITY_LEAD:      models.SeniorityLead,
+	pb.Seniority_SENIORITY_PRINCIPAL: models.SeniorityPrincipal,
+}
+
+// toProtoEnum looks up the proto value of a model value in one of the enum
+// maps above, falling back to the zero (unspecified) value.
+func toProtoEnum[P comparable, M comparable](values map[P]M, value M) P {
+	for protoValue, modelValue := range values {
+		if modelValue == value {
+			return protoValue
+		}
+	}
+	var unspecified P
+	return unspecified
+}
+
+// jobCurrency upper-cases the currency of a job. The salary amounts are
+// validated by the service.
+func jobCurrency(currency string) (string, error) {
+	if currency == "" {
+		return "", nil
+	}
+	code, ok := currencyCode(currency)
+	if !ok {
+		return "", invalidArgument("currency", "must be an ISO 4217 code")
+	}
+	return code, nil
+}
+
+func currencyCode(code string) (string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return "", false
+		}
+	}
+	return code, true
+}
+
+func jobRemotePolicy(mode pb.WorkMode, policy *pb.RemotePolicy) (*models.RemotePolicy, error) {
+	if policy != nil && mode != pb.WorkMode_WORK_MODE_REMOTE {
+		return nil, invalidArgument("remote_policy", "is only allowed for remote jobs")
+	}
+	return fromProtoRemotePolicy(policy)
+}
+
+func fromProtoRemotePolicy(policy *pb.RemotePolicy) (*models.RemotePolicy, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	remote := &models.RemotePolicy{}
+	for _, code := range policy.AllowedCountries {
+		country, ok := countryCode(code)
+		if !ok {
+			return nil, invalidArgument("remote_policy.allowed_countries", "contains the invalid country code %q", code)
+		}
+		remote.AllowedCountries = append(remote.AllowedCountries, country)
+	}
+	if policy.MinUtcOffsetMinutes != nil {
+		offset := int(*policy.MinUtcOffsetMinutes)
+		remote.MinUTCOffsetMinutes = &offset
+	}
+	if policy.MaxUtcOffsetMinutes != nil {
+		offset := int(*policy.MaxUtcOffsetMinutes)
+		remote.MaxUTCOffsetMinutes = &offset
+	}
+
+	for _, offset := range []*int{remote.MinUTCOffsetMinutes, remote.MaxUTCOffsetMinutes} {
+		if offset != nil && !validUTCOffset(*offset) {
+			return nil, invalidArgument("remote_policy", "UTC offsets must be between -720 and 840")
+		}
+	}
+	if remote.MinUTCOffsetMinutes != nil && remote.MaxUTCOffsetMinutes != nil && *remote.MinUTCOffsetMinutes > *remote.MaxUTCOffsetMinutes {
+		return nil, invalidArgument("remote_policy.min_utc_offset_minutes", "must not be greater than max_utc_offset_minutes")
+	}
+
+	return remote, nil
+}
+
+func toProtoRemotePolicy(remote *models.RemotePolicy) *pb.RemotePolicy {
+	if remote == nil {
+		return nil
+	}
+
+	policy := &pb.RemotePolicy{
+		AllowedCountries: remote.AllowedCountries,
+	}
+	if remote.MinUTCOffsetMinutes != nil {
+		offset := int32(*remote.MinUTCOffsetMinutes)
+		policy.MinUtcOffsetMinutes = &offset
+	}
+	if remote.MaxUTCOffsetMinutes != nil {
+		offset := int32(*remote.MaxUTCOffsetMinutes)
+		policy.MaxUtcOffsetMinutes = &offset
+	}
+	return policy
+}
+
+// countryCode upper-cases an ISO 3166-1 alpha-2 code and reports whether it
+// has the right shape.
+func countryCode(code string) (string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+		return "", false
+	}
+	return code, true
+}
+
+// validUTCOffset accepts offsets from UTC-12:00 to UTC+14:00.
+func validUTCOffset(minutes int) bool {
+	return minutes >= -12*60 && minutes <= 14*60
+}
+
+func toProtoGeoPoint(point *models.GeoPoint) *pb.GeoPoint {
+	if point == nil {
+		return nil
+	}
+	return &pb.GeoPoint{Lat: point.Lat, Lon: point.Lon}
+}
+
+func fromProtoGeoPoint(point *pb.GeoPoint) *models.GeoPoint {
+	if point == nil {
+		return nil
+	}
+	return &models.GeoPoint{Lat: point.Lat, Lon: point.Lon}
+}
+
+func toProtoHighlights(highlights map[string][]string) map[string]*pb.HighlightFragments {
+	if len(highlights) == 0 {
+		return nil
+	}
+
+	result := make(map[string]*pb.HighlightFragments, len(highlights))
+	for field, fragments := range highlights {
+		result[field] = &pb.HighlightFragments{Fragments: fragments}
+	}
+	return result
+}
+
+func toProtoExplanation(explanation *models.Explanation) *pb.Explanation {
+	if explanation == nil {
+		return nil
+	}
+
+	details := make([]*pb.Explanation, 0, len(explanation.Details))
+	for i := range explanation.Details {
+		details = append(details, toProtoExplanation(&explanation.Details[i]))
+	}
+	return &pb.Explanation{
+		Value:       explanation.Value,
+		Description: explanation.Description,
+		Details:     details,
+	}
+}
@@ -0,0 +1,561 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"job-search-service/internal/elastic"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// Migrator keeps the configured index name as an alias in front of versioned
+// physical indices (<alias>_v1, <alias>_v2, ...). Migrating to a new mapping
+// version creates the next index, copies the documents over and swaps the
+// alias in a single atomic request, so readers and writers never see a
+// missing index.
+type Migrator struct {
+	client *elastic.Client
+	alias  string
+}
+
+func NewMigrator(client *elastic.Client, alias string) *Migrator {
+	return &Migrator{
+		client: client,
+		alias:  alias,
+	}
+}
+
+type Status struct {
+	Alias   string
+	Index   string
+	Version int
+	Latest  int
+	// Legacy is set when the alias name is still a concrete index created
+	// before indices were versioned.
+	Legacy bool
+}
+
+func (s *Status) Exists() bool {
+	return s.Index != ""
+}
+
+func (s *Status) UpToDate() bool {
+	return s.Exists() && !s.Legacy && s.Version >= s.Latest
+}
+
+func (m *Migrator) IndexName(version int) string {
+	return fmt.Sprintf("%s_v%d", m.alias, version)
+}
+
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	status := &Status{
+		Alias:  m.alias,
+		Latest: elastic.MappingVersion,
+	}
+
+	indices, err := m.aliasIndices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(indices) {
+	case 0:
+		exists, err := m.client.IndexExists(ctx, m.alias)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return status, nil
+		}
+		status.Index = m.alias
+		status.Legacy = true
+	case 1:
+		status.Index = indices[0]
+	default:
+		return nil, fmt.Errorf("alias '%s' points to %d indices, expected one", m.alias, len(indices))
+	}
+
+	status.Version, err = m.client.MappingVersion(ctx, status.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// Bootstrap makes sure the alias exists when the server starts. An empty
+// cluster gets the latest index version; anything older is left for
+// cmd/migrate so that startup never reindexes on its own.
+func (m *Migrator) Bootstrap(ctx context.Context) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !status.Exists():
+		return m.createLatest(ctx)
+	case status.Legacy:
+		log.Printf("Warning: '%s' is a plain index, run cmd/migrate to move it behind an alias", m.alias)
+	case status.Version < status.Latest:
+		log.Printf("Warning: index '%s' has mapping version %d, run cmd/migrate to upgrade to version %d", status.Index, status.Version, status.Latest)
+	case status.Version > status.Latest:
+		log.Printf("Warning: index '%s' has mapping version %d, newer than this build (%d)", status.Index, status.Version, status.Latest)
+	default:
+		log.Printf("Alias '%s' points to '%s' (mapping version %d)", m.alias, status.Index, status.Version)
+	}
+
+	return nil
+}
+
+// Migrate moves the alias to an index built from the latest mapping. It
+// returns the name of the previous index, or "" if nothing was migrated.
+// Job versions handed out before the migration name the old index's
+// generation and are rejected as conflicts afterwards; the old index is left
+// read-only.
+func (m *Migrator) Migrate(ctx context.Context) (string, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if !status.Exists() {
+		return "", m.createLatest(ctx)
+	}
+	if status.UpToDate() {
+		log.Printf("Alias '%s' is already at mapping version %d", m.alias, status.Version)
+		return "", nil
+	}
+
+	source := status.Index
+	target := m.IndexName(status.Latest)
+
+	exists, err := m.client.IndexExists(ctx, target)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		// Left over from an earlier run that failed before the alias swap;
+		// nothing reads from it yet.
+		log.Printf("Removing incomplete index '%s' from a previous migration", target)
+		if err := m.client.DeleteIndex(ctx, target); err != nil {
+			return "", err
+		}
+	}
+
+	if err := m.client.CreateIndex(ctx, target); err != nil {
+		return "", err
+	}
+
+	log.Printf("Reindexing '%s' into '%s'...", source, target)
+	copied, err := m.reindex(ctx, source, target)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Copied %d documents", copied)
+
+	// Writes are blocked while the changes made during the first pass are
+	// carried over, so that nothing written or deleted in the old index is
+	// lost once the alias moves. Writes fail as unavailable until the alias
+	// has been swapped, so clients retry them. The block stays on the old
+	// index after a successful swap, so that conditional writes that were
+	// routed to it by a job version from before the migration keep failing.
+	if err := m.blockWrites(ctx, source, true); err != nil {
+		return "", err
+	}
+	swapped := false
+	defer func() {
+		if swapped {
+			return
+		}
+		if err := m.blockWrites(ctx, source, false); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}()
+
+	// External versioning skips anything the first pass already copied.
+	caughtUp, err := m.reindex(ctx, source, target)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Caught up %d documents written during the reindex", caughtUp)
+
+	deleted, err := m.removeDeleted(ctx, source, target)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Removed %d documents deleted during the reindex", deleted)
+
+	actions := []interface{}{
+		map[string]interface{}{
+			"add": map[string]interface{}{"index": target, "alias": m.alias, "is_write_index": true},
+		},
+	}
+	if status.Legacy {
+		actions = append(actions, map[string]interface{}{
+			"remove_index": map[string]interface{}{"index": source},
+		})
+	} else {
+		actions = append(actions, map[string]interface{}{
+			"remove": map[string]interface{}{"index": source, "alias": m.alias},
+		})
+	}
+	if err := m.updateAliases(ctx, actions); err != nil {
+		return "", err
+	}
+	swapped = true
+	log.Printf("Alias '%s' now points to '%s'", m.alias, target)
+
+	if err := m.record(ctx, status.Latest, target, source, copied); err != nil {
+		return "", err
+	}
+
+	if status.Legacy {
+		return "", nil
+	}
+	return source, nil
+}
+
+func (m *Migrator) createLatest(ctx context.Context) error {
+	index := m.IndexName(elastic.MappingVersion)
+
+	if err := m.client.CreateIndex(ctx, index); err != nil {
+		return err
+	}
+
+	err := m.updateAliases(ctx, []interface{}{
+		map[string]interface{}{
+			"add": map[string]interface{}{"index": index, "alias": m.alias, "is_write_index": true},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("Alias '%s' now points to '%s'", m.alias, index)
+
+	return m.record(ctx, elastic.MappingVersion, index, "", 0)
+}
+
+func (m *Migrator) aliasIndices(ctx context.Context) ([]string, error) {
+	es := m.client.ES
+
+	res, err := es.Indices.GetAlias(
+		es.Indices.GetAlias.WithContext(ctx),
+		es.Indices.GetAlias.WithName(m.alias),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting alias: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("error getting alias: %s", res.String())
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error parsing alias response: %w", err)
+	}
+
+	indices := make([]string, 0, len(result))
+	for index := range result {
+		indices = append(indices, index)
+	}
+
+	return indices, nil
+}
+
+// backfillScript fills in fields derived at index time for documents written
+// before they existed. Jobs from before mapping version 5 only have a single
+// salary, which was always a yearly amount in the base currency.
+const backfillScript = `
+if (ctx._source.annual_salary_min == null && ctx._source.salary != null && ctx._source.salary > 0) {
+	ctx._source.annual_salary_min = ctx._source.salary;
+	ctx._source.annual_salary_max = ctx._source.salary;
+}
+`
+
+func (m *Migrator) reindex(ctx context.Context, source, target string) (int64, error) {
+	es := m.client.ES
+
+	body, err := json.Marshal(map[string]interface{}{
+		"conflicts": "proceed",
+		"source": map[string]interface{}{
+			"index": source,
+		},
+		"dest": map[string]interface{}{
+			"index":        target,
+			"version_type": "external",
+		},
+		"script": map[string]interface{}{
+			"lang":   "painless",
+			"source": backfillScript,
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error encoding reindex request: %w", err)
+	}
+
+	res, err := es.Reindex(
+		bytes.NewReader(body),
+		es.Reindex.WithContext(ctx),
+		es.Reindex.WithRefresh(true),
+		es.Reindex.WithWaitForCompletion(true),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error reindexing: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, fmt.Errorf("error reindexing: %s", res.String())
+	}
+
+	var result struct {
+		Created  int64             `json:"created"`
+		Updated  int64             `json:"updated"`
+		Failures []json.RawMessage `json:"failures"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("error parsing reindex response: %w", err)
+	}
+
+	if len(result.Failures) > 0 {
+		return 0, fmt.Errorf("reindex reported %d failures, first: %s", len(result.Failures), result.Failures[0])
+	}
+
+	return result.Created + result.Updated, nil
+}
+
+// blockWrites sets or clears the write block of index.
+func (m *Migrator) blockWrites(ctx context.Context, index string, block bool) error {
+	es := m.client.ES
+
+	body, err := json.Marshal(map[string]interface{}{
+		"index.blocks.write": block,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding index settings: %w", err)
+	}
+
+	res, err := es.Indices.PutSettings(
+		bytes.NewReader(body),
+		es.Indices.PutSettings.WithContext(ctx),
+		es.Indices.PutSettings.WithIndex(index),
+	)
+	if err != nil {
+		return fmt.Errorf("error updating write block of '%s': %w", index, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("error updating write block of '%s': %s", index, res.String())
+	}
+
+	return nil
+}
+
+// removeDeleted deletes the documents of target that no longer exist in
+// source. The reindex only copies documents, so a job deleted after the first
+// pass copied it would otherwise come back.
+func (m *Migrator) removeDeleted(ctx context.Context, source, target string) (int, error) {
+	sourceIDs, err := m.documentIDs(ctx, source)
+	if err != nil {
+		return 0, err
+	}
+	targetIDs, err := m.documentIDs(ctx, target)
+	if err != nil {
+		return 0, err
+	}
+
+	var body bytes.Buffer
+	deleted := 0
+	for id := range targetIDs {
+		if _, ok := sourceIDs[id]; ok {
+			continue
+		}
+		action, err := json.Marshal(map[string]interface{}{
+			"delete": map[string]interface{}{"_index": target, "_id": id},
+		})
+		if err != nil {
+			return 0, fmt.Errorf("error encoding delete action: %w", err)
+		}
+		body.Write(action)
+		body.WriteByte('\n')
+		deleted++
+	}
+	if deleted == 0 {
+		return 0, nil
+	}
+
+	es := m.client.ES
+	res, err := es.Bulk(
+		&body,
+		es.Bulk.WithContext(ctx),
+		es.Bulk.WithRefresh("true"),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting documents: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, fmt.Errorf("error deleting documents: %s", res.String())
+	}
+
+	var result struct {
+		Errors bool `json:"errors"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("error parsing bulk response: %w", err)
+	}
+	if result.Errors {
+		return 0, fmt.Errorf("error deleting documents from '%s'", target)
+	}
+
+	return deleted, nil
+}
+
+// documentIDs scrolls through index and returns the IDs of all its
+// documents.
+func (m *Migrator) documentIDs(ctx context.Context, index string) (map[string]struct{}, error) {
+	es := m.client.ES
+
+	res, err := es.Search(
+		es.Search.WithContext(ctx),
+		es.Search.WithIndex(index),
+		es.Search.WithScroll(time.Minute),
+		es.Search.WithSize(idPageSize),
+		es.Search.WithSource("false"),
+		es.Search.WithSort("_doc"),
+	)
+	ids := map[string]struct{}{}
+	var scrollID string
+	defer func() {
+		if scrollID != "" {
+			m.clearScroll(scrollID)
+		}
+	}()
+
+	for {
+		if err != nil {
+			return nil, fmt.Errorf("error listing documents of '%s': %w", index, err)
+		}
+
+		var page struct {
+			ScrollID string `json:"_scroll_id"`
+			Hits     struct {
+				Hits []struct {
+					ID string `json:"_id"`
+				} `json:"hits"`
+			} `json:"hits"`
+		}
+		if res.IsError() {
+			err = fmt.Errorf("error listing documents of '%s': %s", index, res.String())
+		} else if decodeErr := json.NewDecoder(res.Body).Decode(&page); decodeErr != nil {
+			err = fmt.Errorf("error parsing documents of '%s': %w", index, decodeErr)
+		}
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if page.ScrollID != "" {
+			scrollID = page.ScrollID
+		}
+
+		if len(page.Hits.Hits) == 0 {
+			return ids, nil
+		}
+		for _, hit := range page.Hits.Hits {
+			ids[hit.ID] = struct{}{}
+		}
+
+		res, err = es.Scroll(
+			es.Scroll.WithContext(ctx),
+			es.Scroll.WithScrollID(scrollID),
+			es.Scroll.WithScroll(time.Minute),
+		)
+	}
+}
+
+// idPageSize is the number of document IDs fetched per scroll request.
+const idPageSize = 1000
+
+func (m *Migrator) clearScroll(scrollID string) {
+	es := m.client.ES
+
+	res, err := es.ClearScroll(es.ClearScroll.WithScrollID(scrollID))
+	if err != nil {
+		log.Printf("Warning: error clearing scroll: %v", err)
+		return
+	}
+	res.Body.Close()
+}
+
+func (m *Migrator) updateAliases(ctx context.Context, actions []interface{}) error {
+	es := m.client.ES
+
+	body, err := json.Marshal(map[string]interface{}{
+		"actions": actions,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding alias actions: %w", err)
+	}
+
+	res, err := es.Indices.UpdateAliases(
+		bytes.NewReader(body),
+		es.Indices.UpdateAliases.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("error updating aliases: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("error updating aliases: %s", res.String())
+	}
+
+	return nil
+}
+
+// record keeps a history of applied mapping versions in <alias>_migrations.
+func (m *Migrator) record(ctx context.Context, version int, index, source string, documents int64) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"version":      version,
+		"index":        index,
+		"source_index": source,
+		"documents":    documents,
+		"applied_at":   time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding migration record: %w", err)
+	}
+
+	req := esapi.IndexRequest{
+		Index:      m.alias + "_migrations",
+		DocumentID: strconv.Itoa(version),
+		Body:       bytes.NewReader(body),
+		Refresh:    "true",
+	}
+
+	res, err := req.Do(ctx, m.client.ES)
+	if err != nil {
+		return fmt.Errorf("error recording migration: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("error recording migration: %s", res.String())
+	}
+
+	log.Printf("Recorded mapping version %d for alias '%s'", version, m.alias)
+	return nil
+}
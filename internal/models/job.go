@@ -3,13 +3,95 @@ package models
 import "time"
 
 type Job struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Company     string    `json:"company"`
-	Location    string    `json:"location"`
-	Skills      []string  `json:"skills"`
-	Salary      float64   `json:"salary"`
-	CreatedAt   time.Time `json:"created_at"`
-	Score       float64   `json:"score,omitempty"`
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Company     string   `json:"company"`
+	Location    string   `json:"location"`
+	Skills      []string `json:"skills"`
+	Salary      float64  `json:"salary"`
+	// SalaryMin and SalaryMax are in Currency per PayPeriod. Jobs without a
+	// range fall back to Salary as a yearly amount in the base currency.
+	SalaryMin      float64        `json:"salary_min,omitempty"`
+	SalaryMax      float64        `json:"salary_max,omitempty"`
+	Currency       string         `json:"currency,omitempty"`
+	PayPeriod      PayPeriod      `json:"pay_period,omitempty"`
+	EmploymentType EmploymentType `json:"employment_type,omitempty"`
+	Seniority      Seniority      `json:"seniority,omitempty"`
+	// AnnualSalaryMin and AnnualSalaryMax are the salary range converted to
+	// a yearly amount in the base currency, used for filtering and sorting.
+	AnnualSalaryMin float64       `json:"annual_salary_min,omitempty"`
+	AnnualSalaryMax float64       `json:"annual_salary_max,omitempty"`
+	Geo             *GeoPoint     `json:"geo,omitempty"`
+	WorkMode        WorkMode      `json:"work_mode,omitempty"`
+	Remote          *RemotePolicy `json:"remote_policy,omitempty"`
+	Featured        bool          `json:"featured,omitempty"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+	Score           float64       `json:"score,omitempty"`
+	Version         string        `json:"-"`
+	// Highlights holds highlighted fragments per field for search hits.
+	Highlights map[string][]string `json:"-"`
+	// DistanceKm is the distance from the search origin for geo searches.
+	DistanceKm float64 `json:"-"`
+	// MatchedSkills lists the requested skills a search hit has.
+	MatchedSkills []string `json:"-"`
+	// Explanation is set for search hits when SearchParams.Explain is set.
+	Explanation *Explanation `json:"-"`
+}
+
+type WorkMode string
+
+const (
+	WorkModeOnsite WorkMode = "onsite"
+	WorkModeHybrid WorkMode = "hybrid"
+	WorkModeRemote WorkMode = "remote"
+)
+
+type PayPeriod string
+
+const (
+	PayPeriodHourly  PayPeriod = "hourly"
+	PayPeriodMonthly PayPeriod = "monthly"
+	PayPeriodYearly  PayPeriod = "yearly"
+)
+
+type EmploymentType string
+
+const (
+	EmploymentFullTime   EmploymentType = "full_time"
+	EmploymentPartTime   EmploymentType = "part_time"
+	EmploymentContract   EmploymentType = "contract"
+	EmploymentInternship EmploymentType = "internship"
+)
+
+type Seniority string
+
+const (
+	SeniorityJunior    Seniority = "junior"
+	SeniorityMid       Seniority = "mid"
+	SenioritySenior    Seniority = "senior"
+	SeniorityLead      Seniority = "lead"
+	SeniorityPrincipal Seniority = "principal"
+)
+
+// RemotePolicy restricts where candidates of a remote job may live. Empty
+// fields mean no restriction.
+type RemotePolicy struct {
+	// AllowedCountries holds ISO 3166-1 alpha-2 codes.
+	AllowedCountries    []string `json:"allowed_countries,omitempty"`
+	MinUTCOffsetMinutes *int     `json:"min_utc_offset_minutes,omitempty"`
+	MaxUTCOffsetMinutes *int     `json:"max_utc_offset_minutes,omitempty"`
+}
+
+// Explanation describes how a search score was computed.
+type Explanation struct {
+	Value       float64       `json:"value"`
+	Description string        `json:"description"`
+	Details     []Explanation `json:"details,omitempty"`
+}
+
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
 }
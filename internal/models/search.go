@@ -0,0 +1,124 @@
+package models
+
+import (
+	"job-search-service/internal/ranking"
+	"time"
+)
+
+type SearchParams struct {
+	Query    string
+	Location string
+	Skills   []string
+	// SkillsMatch decides how many of Skills a job needs; with
+	// SkillsMatchAtLeast that is SkillsMinimumMatch.
+	SkillsMatch        SkillsMatchMode
+	SkillsMinimumMatch int
+	// MinSalary and MaxSalary are in SalaryCurrency per SalaryPeriod and
+	// match jobs whose salary range overlaps them.
+	MinSalary      *float64
+	MaxSalary      *float64
+	SalaryCurrency string
+	SalaryPeriod   PayPeriod
+	PostedAfter    time.Time
+	PostedBefore   time.Time
+	PageSize       int
+	PageToken      string
+	Sort           []SortOption
+	Facets         []FacetRequest
+	Highlight      *HighlightOptions
+	// AutoCorrect reruns a search without results using the suggested
+	// spelling correction of Query.
+	AutoCorrect bool
+	Near        *GeoDistance
+	WorkModes   []WorkMode
+	// RemoteCountry and RemoteUTCOffsetMinutes describe the candidate and
+	// only exclude remote jobs whose remote policy does not allow them.
+	RemoteCountry          string
+	RemoteUTCOffsetMinutes *int
+	EmploymentTypes        []EmploymentType
+	Seniorities            []Seniority
+	// RankingProfile names a profile from the config; Ranking is the
+	// resolved profile used to score results.
+	RankingProfile string
+	Ranking        *ranking.Profile
+	// Explain requests the scoring explanation of every hit.
+	Explain bool
+}
+
+// GeoDistance limits results to jobs within RadiusKm of Origin. The origin can
+// be given as coordinates or as a city name resolved with the gazetteer; a
+// zero radius only sets the origin for distance sorting.
+type GeoDistance struct {
+	Origin         *GeoPoint
+	OriginLocation string
+	RadiusKm       float64
+}
+
+type HighlightOptions struct {
+	PreTag            string
+	PostTag           string
+	FragmentSize      int
+	NumberOfFragments int
+}
+
+type SkillsMatchMode string
+
+const (
+	SkillsMatchAny     SkillsMatchMode = "any"
+	SkillsMatchAll     SkillsMatchMode = "all"
+	SkillsMatchAtLeast SkillsMatchMode = "at_least"
+)
+
+type SortField string
+
+const (
+	SortByRelevance SortField = "relevance"
+	SortByCreatedAt SortField = "created_at"
+	SortBySalary    SortField = "salary"
+	SortByTitle     SortField = "title"
+	SortByCompany   SortField = "company"
+	SortByDistance  SortField = "distance"
+)
+
+type SortOption struct {
+	Field      SortField
+	Descending bool
+}
+
+type FacetField string
+
+const (
+	FacetSkills         FacetField = "skills"
+	FacetLocation       FacetField = "location"
+	FacetCompany        FacetField = "company"
+	FacetSalary         FacetField = "salary"
+	FacetCreatedAt      FacetField = "created_at"
+	FacetEmploymentType FacetField = "employment_type"
+	FacetSeniority      FacetField = "seniority"
+)
+
+type FacetRequest struct {
+	Field FacetField
+	// Size limits the number of buckets of skills, location and company.
+	Size int
+	// Interval is the bucket width of the salary histogram.
+	Interval float64
+	// CalendarInterval is the bucket width of the created_at histogram.
+	CalendarInterval string
+}
+
+type FacetBucket struct {
+	Key   string
+	Count int64
+}
+
+type SearchResult struct {
+	Jobs          []*Job
+	Total         int64
+	NextPageToken string
+	Facets        map[FacetField][]FacetBucket
+	// SuggestedQuery is a spelling correction of a query without results.
+	SuggestedQuery string
+	// QueryCorrected is set when the results are for SuggestedQuery.
+	QueryCorrected bool
+}
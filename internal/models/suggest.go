@@ -0,0 +1,16 @@
+package models
+
+type SuggestField string
+
+const (
+	SuggestTitle   SuggestField = "title"
+	SuggestCompany SuggestField = "company"
+	SuggestSkill   SuggestField = "skill"
+)
+
+type Suggestion struct {
+	Text string
+	// Score is the relevance score for titles and companies and the number
+	// of matching jobs for skills.
+	Score float64
+}
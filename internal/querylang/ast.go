@@ -0,0 +1,74 @@
+package querylang
+
+import "strings"
+
+// Node is a parsed query expression. Pos is the 1-based character position
+// of the expression in the query string.
+type Node interface {
+	Pos() int
+}
+
+// And matches when all clauses match. Juxtaposed terms are joined by And.
+type And struct {
+	Clauses []Node
+	At      int
+}
+
+// Or matches when any clause matches.
+type Or struct {
+	Clauses []Node
+	At      int
+}
+
+// Not matches when its clause does not match. Written as -term or NOT term.
+type Not struct {
+	Clause Node
+	At     int
+}
+
+// Term matches a word or quoted phrase, either in the free-text fields or in
+// a single field (title:go).
+type Term struct {
+	Field  string
+	Value  string
+	Phrase bool
+	At     int
+}
+
+// Range compares a numeric or date field (salary:>90000, posted:>=2026-01-01,
+// salary:60000..80000). Either bound may be empty.
+type Range struct {
+	Field string
+	// From and To are the bounds; the Include flags make them inclusive.
+	From, To               string
+	IncludeFrom, IncludeTo bool
+	At                     int
+}
+
+func (n *And) Pos() int   { return n.At }
+func (n *Or) Pos() int    { return n.At }
+func (n *Not) Pos() int   { return n.At }
+func (n *Term) Pos() int  { return n.At }
+func (n *Range) Pos() int { return n.At }
+
+// PlainText returns the words of a query that uses no syntax at all, such as
+// "golang developer". Queries with fields, phrases or operators report false.
+func PlainText(node Node) (string, bool) {
+	switch n := node.(type) {
+	case *Term:
+		if n.Field == "" && !n.Phrase {
+			return n.Value, true
+		}
+	case *And:
+		words := make([]string, 0, len(n.Clauses))
+		for _, clause := range n.Clauses {
+			term, ok := clause.(*Term)
+			if !ok || term.Field != "" || term.Phrase {
+				return "", false
+			}
+			words = append(words, term.Value)
+		}
+		return strings.Join(words, " "), true
+	}
+	return "", false
+}
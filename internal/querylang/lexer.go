@@ -0,0 +1,154 @@
+package querylang
+
+import (
+	"fmt"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenPhrase
+	tokenColon
+	tokenMinus
+	tokenLParen
+	tokenRParen
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokenEOF:
+		return "end of query"
+	case tokenWord:
+		return "word"
+	case tokenPhrase:
+		return "phrase"
+	case tokenColon:
+		return "':'"
+	case tokenMinus:
+		return "'-'"
+	case tokenLParen:
+		return "'('"
+	case tokenRParen:
+		return "')'"
+	case tokenAnd:
+		return "AND"
+	case tokenOr:
+		return "OR"
+	case tokenNot:
+		return "NOT"
+	default:
+		return fmt.Sprintf("token(%d)", int(k))
+	}
+}
+
+type token struct {
+	kind  tokenKind
+	value string
+	// pos is the 1-based character position of the token.
+	pos int
+	// spaceBefore is set when whitespace separates the token from the
+	// previous one, which tells a field value (title:go) from a new term.
+	spaceBefore bool
+}
+
+// SyntaxError reports an invalid query together with the position of the
+// offending token so that clients can point at it.
+type SyntaxError struct {
+	// Pos is the 1-based character position in the query.
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+func errorf(pos int, format string, args ...interface{}) *SyntaxError {
+	return &SyntaxError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// lex splits a query into tokens. Words run until whitespace or one of
+// ( ) : ", and a leading - negates the term it is attached to. AND, OR and NOT
+// are operators only when written in upper case.
+func lex(query string) ([]token, error) {
+	runes := []rune(query)
+	tokens := []token{}
+	space := true
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			space = true
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, value: "(", pos: pos, spaceBefore: space})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, value: ")", pos: pos, spaceBefore: space})
+			i++
+		case r == ':':
+			tokens = append(tokens, token{kind: tokenColon, value: ":", pos: pos, spaceBefore: space})
+			i++
+		case r == '-' && (space || previousIs(tokens, tokenLParen)) && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]):
+			tokens = append(tokens, token{kind: tokenMinus, value: "-", pos: pos, spaceBefore: space})
+			i++
+		case r == '"':
+			value := []rune{}
+			closed := false
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					value = append(value, runes[i])
+					continue
+				}
+				if runes[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				value = append(value, runes[i])
+			}
+			if !closed {
+				return nil, errorf(pos, "unterminated quoted phrase")
+			}
+			tokens = append(tokens, token{kind: tokenPhrase, value: string(value), pos: pos, spaceBefore: space})
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !isDelimiter(runes[i]) {
+				i++
+			}
+			word := string(runes[start:i])
+			kind := tokenWord
+			switch word {
+			case "AND":
+				kind = tokenAnd
+			case "OR":
+				kind = tokenOr
+			case "NOT":
+				kind = tokenNot
+			}
+			tokens = append(tokens, token{kind: kind, value: word, pos: pos, spaceBefore: space})
+		}
+		space = false
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes) + 1, spaceBefore: true}), nil
+}
+
+func isDelimiter(r rune) bool {
+	return r == '(' || r == ')' || r == ':' || r == '"'
+}
+
+func previousIs(tokens []token, kind tokenKind) bool {
+	return len(tokens) > 0 && tokens[len(tokens)-1].kind == kind
+}
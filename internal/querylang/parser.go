@@ -0,0 +1,290 @@
+package querylang
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits bound the size of a query so that a single search cannot turn into
+// an expensive Elasticsearch query. Zero means no limit.
+type Limits struct {
+	// MaxLength is the maximum number of characters.
+	MaxLength int
+	// MaxTerms is the maximum number of terms and ranges.
+	MaxTerms int
+}
+
+// Parse turns a search string such as
+//
+//	title:"senior backend" -php skills:go salary:>90000 location:berlin
+//
+// into a query tree. Terms separated by whitespace must all match; OR, NOT,
+// a leading - and parentheses combine them further. An empty query returns a
+// nil node. Errors, including exceeded limits, are *SyntaxError.
+func Parse(query string, limits Limits) (Node, error) {
+	if limits.MaxLength > 0 && utf8.RuneCountInString(query) > limits.MaxLength {
+		return nil, errorf(limits.MaxLength+1, "query is longer than %d characters", limits.MaxLength)
+	}
+
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+	if tokens[0].kind == tokenEOF {
+		return nil, nil
+	}
+
+	p := &parser{tokens: tokens, maxTerms: limits.MaxTerms}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errorf(tok.pos, "unexpected %s", describe(tok))
+	}
+
+	return node, nil
+}
+
+type parser struct {
+	tokens   []token
+	i        int
+	terms    int
+	maxTerms int
+}
+
+// countTerm enforces Limits.MaxTerms for the term starting at pos.
+func (p *parser) countTerm(pos int) error {
+	p.terms++
+	if p.maxTerms > 0 && p.terms > p.maxTerms {
+		return errorf(pos, "query has more than %d terms", p.maxTerms)
+	}
+	return nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.i]
+	if tok.kind != tokenEOF {
+		p.i++
+	}
+	return tok
+}
+
+func (p *parser) parseOr() (Node, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	clauses := []Node{first}
+	for p.peek().kind == tokenOr {
+		op := p.next()
+		if !startsTerm(p.peek()) {
+			return nil, errorf(op.pos, "expected a term after OR")
+		}
+		clause, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause)
+	}
+
+	if len(clauses) == 1 {
+		return first, nil
+	}
+	return &Or{Clauses: clauses, At: first.Pos()}, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	if tok := p.peek(); !startsTerm(tok) {
+		return nil, errorf(tok.pos, "expected a term, found %s", describe(tok))
+	}
+
+	first, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	clauses := []Node{first}
+	for {
+		tok := p.peek()
+		if tok.kind == tokenAnd {
+			p.next()
+			if !startsTerm(p.peek()) {
+				return nil, errorf(tok.pos, "expected a term after AND")
+			}
+		} else if !startsTerm(tok) {
+			break
+		}
+
+		clause, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause)
+	}
+
+	if len(clauses) == 1 {
+		return first, nil
+	}
+	return &And{Clauses: clauses, At: first.Pos()}, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	tok := p.peek()
+	if tok.kind != tokenMinus && tok.kind != tokenNot {
+		return p.parsePrimary()
+	}
+
+	p.next()
+	if !startsTerm(p.peek()) {
+		return nil, errorf(tok.pos, "expected a term after %s", describe(tok))
+	}
+	clause, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &Not{Clause: clause, At: tok.pos}, nil
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.next()
+	if tok.kind == tokenPhrase || tok.kind == tokenWord {
+		if err := p.countTerm(tok.pos); err != nil {
+			return nil, err
+		}
+	}
+
+	switch tok.kind {
+	case tokenLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenRParen {
+			return nil, errorf(tok.pos, "unclosed '('")
+		}
+		p.next()
+		return node, nil
+	case tokenPhrase:
+		return &Term{Value: tok.value, Phrase: true, At: tok.pos}, nil
+	case tokenWord:
+		if colon := p.peek(); colon.kind == tokenColon && !colon.spaceBefore {
+			p.next()
+			return p.parseField(tok, colon)
+		}
+		return &Term{Value: tok.value, At: tok.pos}, nil
+	default:
+		return nil, errorf(tok.pos, "unexpected %s", describe(tok))
+	}
+}
+
+func (p *parser) parseField(name, colon token) (Node, error) {
+	fieldName := strings.ToLower(name.value)
+	f, ok := fields[fieldName]
+	if !ok {
+		return nil, errorf(name.pos, "unknown field %q, expected one of %s", name.value, fieldNames())
+	}
+
+	value := p.peek()
+	if (value.kind != tokenWord && value.kind != tokenPhrase) || value.spaceBefore {
+		return nil, errorf(colon.pos, "expected a value directly after '%s:'", name.value)
+	}
+	p.next()
+
+	switch f.kind {
+	case numberField, dateField:
+		if value.kind == tokenPhrase {
+			return nil, errorf(value.pos, "%s expects %s", fieldName, f.kind)
+		}
+		return parseRange(fieldName, f, name.pos, value)
+	case keywordField:
+		// Accept on-site, full-time, FULL_TIME and the like.
+		for _, allowed := range f.values {
+			if squash(value.value) == squash(allowed) {
+				return &Term{Field: fieldName, Value: allowed, At: name.pos}, nil
+			}
+		}
+		return nil, errorf(value.pos, "invalid %s %q, expected one of %s", fieldName, value.value, strings.Join(f.values, ", "))
+	default:
+		return &Term{Field: fieldName, Value: value.value, Phrase: value.kind == tokenPhrase, At: name.pos}, nil
+	}
+}
+
+// parseRange reads >v, >=v, <v, <=v, from..to (either side may be empty) or
+// an exact value, which matches the whole day for dates.
+func parseRange(fieldName string, f field, pos int, value token) (Node, error) {
+	node := &Range{Field: fieldName, At: pos}
+	text := value.value
+
+	switch {
+	case strings.HasPrefix(text, ">="):
+		node.From, node.IncludeFrom = text[2:], true
+	case strings.HasPrefix(text, ">"):
+		node.From = text[1:]
+	case strings.HasPrefix(text, "<="):
+		node.To, node.IncludeTo = text[2:], true
+	case strings.HasPrefix(text, "<"):
+		node.To = text[1:]
+	case strings.Contains(text, ".."):
+		node.From, node.To, _ = strings.Cut(text, "..")
+		node.IncludeFrom, node.IncludeTo = true, true
+	default:
+		node.From, node.To = text, text
+		node.IncludeFrom, node.IncludeTo = true, true
+	}
+
+	if node.From == "" && node.To == "" {
+		return nil, errorf(value.pos, "%s expects %s", fieldName, f.kind)
+	}
+	for _, bound := range []string{node.From, node.To} {
+		if bound != "" && !f.kind.valid(bound) {
+			return nil, errorf(value.pos, "%s expects %s, got %q", fieldName, f.kind, bound)
+		}
+	}
+
+	return node, nil
+}
+
+func (k fieldKind) valid(value string) bool {
+	switch k {
+	case numberField:
+		n, err := strconv.ParseFloat(value, 64)
+		return err == nil && n >= 0
+	case dateField:
+		_, err := time.Parse(dateLayout, value)
+		return err == nil
+	default:
+		return true
+	}
+}
+
+func squash(value string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(value))
+}
+
+func startsTerm(tok token) bool {
+	switch tok.kind {
+	case tokenWord, tokenPhrase, tokenMinus, tokenNot, tokenLParen:
+		return true
+	default:
+		return false
+	}
+}
+
+func describe(tok token) string {
+	switch tok.kind {
+	case tokenWord:
+		return strconv.Quote(tok.value)
+	case tokenPhrase:
+		return "phrase " + strconv.Quote(tok.value)
+	default:
+		return tok.kind.String()
+	}
+}
@@ -0,0 +1,196 @@
+package querylang
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Node
+	}{
+		{
+			query: "",
+			want:  nil,
+		},
+		{
+			query: "golang",
+			want:  &Term{Value: "golang", At: 1},
+		},
+		{
+			query: `title:"senior backend" -php skills:go salary:>90000 location:berlin`,
+			want: &And{At: 1, Clauses: []Node{
+				&Term{Field: "title", Value: "senior backend", Phrase: true, At: 1},
+				&Not{Clause: &Term{Value: "php", At: 25}, At: 24},
+				&Term{Field: "skills", Value: "go", At: 29},
+				&Range{Field: "salary", From: "90000", At: 39},
+				&Term{Field: "location", Value: "berlin", At: 53},
+			}},
+		},
+		{
+			query: "go OR rust",
+			want: &Or{At: 1, Clauses: []Node{
+				&Term{Value: "go", At: 1},
+				&Term{Value: "rust", At: 7},
+			}},
+		},
+		{
+			query: "(go OR rust) AND NOT php",
+			want: &And{At: 2, Clauses: []Node{
+				&Or{At: 2, Clauses: []Node{
+					&Term{Value: "go", At: 2},
+					&Term{Value: "rust", At: 8},
+				}},
+				&Not{Clause: &Term{Value: "php", At: 22}, At: 18},
+			}},
+		},
+		{
+			query: "salary:60000..80000 posted:>=2026-01-01",
+			want: &And{At: 1, Clauses: []Node{
+				&Range{Field: "salary", From: "60000", To: "80000", IncludeFrom: true, IncludeTo: true, At: 1},
+				&Range{Field: "posted", From: "2026-01-01", IncludeFrom: true, At: 21},
+			}},
+		},
+		{
+			query: "posted:2026-03-01",
+			want:  &Range{Field: "posted", From: "2026-03-01", To: "2026-03-01", IncludeFrom: true, IncludeTo: true, At: 1},
+		},
+		{
+			query: "mode:on-site type:FULL_TIME",
+			want: &And{At: 1, Clauses: []Node{
+				&Term{Field: "mode", Value: "onsite", At: 1},
+				&Term{Field: "type", Value: "full_time", At: 14},
+			}},
+		},
+		{
+			query: `"c\"sharp"`,
+			want:  &Term{Value: `c"sharp`, Phrase: true, At: 1},
+		},
+		{
+			// Operators are only recognised in upper case.
+			query: "go or rust",
+			want: &And{At: 1, Clauses: []Node{
+				&Term{Value: "go", At: 1},
+				&Term{Value: "or", At: 4},
+				&Term{Value: "rust", At: 7},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := Parse(tt.query, Limits{})
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.query, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %s, want %s", tt.query, dump(got), dump(tt.want))
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query  string
+		limits Limits
+		pos    int
+		msg    string
+	}{
+		{query: `title:"senior backend`, pos: 7, msg: "unterminated quoted phrase"},
+		{query: "title:", pos: 6, msg: "expected a value directly after 'title:'"},
+		{query: "title: go", pos: 6, msg: "expected a value directly after 'title:'"},
+		{query: "a OR", pos: 3, msg: "expected a term after OR"},
+		{query: "a AND", pos: 3, msg: "expected a term after AND"},
+		{query: "NOT", pos: 1, msg: "expected a term after NOT"},
+		{query: "(", pos: 2, msg: "expected a term, found end of query"},
+		{query: "(go rust", pos: 1, msg: "unclosed '('"},
+		{query: "go )", pos: 4, msg: "unexpected ')'"},
+		{query: "OR go", pos: 1, msg: "expected a term, found OR"},
+		{query: "foo:bar", pos: 1, msg: `unknown field "foo"`},
+		{query: "salary:>abc", pos: 8, msg: `salary expects a number, got "abc"`},
+		{query: "salary:-5", pos: 8, msg: `salary expects a number, got "-5"`},
+		{query: "salary:..", pos: 8, msg: "salary expects a number"},
+		{query: "salary:1000..x", pos: 8, msg: `salary expects a number, got "x"`},
+		{query: "posted:2026-13-01", pos: 8, msg: `posted expects a date (YYYY-MM-DD), got "2026-13-01"`},
+		{query: "posted:>01/02/2026", pos: 8, msg: "posted expects a date (YYYY-MM-DD)"},
+		{query: `posted:"2026-01-01"`, pos: 8, msg: "posted expects a date (YYYY-MM-DD)"},
+		{query: "mode:office", pos: 6, msg: `invalid mode "office"`},
+		{query: "golang developer", limits: Limits{MaxLength: 10}, pos: 11, msg: "longer than 10 characters"},
+		{query: "a b c", limits: Limits{MaxTerms: 2}, pos: 5, msg: "more than 2 terms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(tt.query, tt.limits)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) error = %v, want a *SyntaxError", tt.query, err)
+			}
+			if syntaxErr.Pos != tt.pos {
+				t.Errorf("Parse(%q) error position = %d, want %d (%v)", tt.query, syntaxErr.Pos, tt.pos, err)
+			}
+			if !strings.Contains(syntaxErr.Msg, tt.msg) {
+				t.Errorf("Parse(%q) error = %q, want it to contain %q", tt.query, syntaxErr.Msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+		ok    bool
+	}{
+		{query: "golang", want: "golang", ok: true},
+		{query: "golang developer", want: "golang developer", ok: true},
+		{query: `"golang developer"`},
+		{query: "title:golang"},
+		{query: "golang -php"},
+		{query: "golang OR rust"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			node, err := Parse(tt.query, Limits{})
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.query, err)
+			}
+			got, ok := PlainText(node)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("PlainText(%q) = %q, %v, want %q, %v", tt.query, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+// dump renders a query tree for failure messages.
+func dump(node Node) string {
+	switch n := node.(type) {
+	case *And:
+		return fmt.Sprintf("And@%d(%s)", n.At, dumpAll(n.Clauses))
+	case *Or:
+		return fmt.Sprintf("Or@%d(%s)", n.At, dumpAll(n.Clauses))
+	case *Not:
+		return fmt.Sprintf("Not@%d(%s)", n.At, dump(n.Clause))
+	case *Term:
+		return fmt.Sprintf("%+v", *n)
+	case *Range:
+		return fmt.Sprintf("%+v", *n)
+	default:
+		return fmt.Sprint(node)
+	}
+}
+
+func dumpAll(nodes []Node) string {
+	parts := make([]string, len(nodes))
+	for i, node := range nodes {
+		parts[i] = dump(node)
+	}
+	return strings.Join(parts, " ")
+}
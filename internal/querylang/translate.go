@@ -0,0 +1,254 @@
+package querylang
+
+import (
+	"job-search-service/internal/models"
+	"sort"
+	"strings"
+)
+
+type fieldKind int
+
+const (
+	textField fieldKind = iota
+	skillField
+	keywordField
+	numberField
+	dateField
+)
+
+func (k fieldKind) String() string {
+	switch k {
+	case numberField:
+		return "a number"
+	case dateField:
+		return "a date (YYYY-MM-DD)"
+	default:
+		return "a value"
+	}
+}
+
+const dateLayout = "2006-01-02"
+
+type field struct {
+	kind fieldKind
+	path string
+	// values lists the accepted values of keyword fields.
+	values []string
+}
+
+// fields maps the field names of the query language to the index. Salary
+// bounds compare against the annual salary range in the base currency.
+var fields = map[string]field{
+	"title":       {kind: textField, path: "title"},
+	"description": {kind: textField, path: "description"},
+	"company":     {kind: textField, path: "company"},
+	"location":    {kind: textField, path: "location"},
+	"skills":      {kind: skillField, path: "skills.text"},
+	"skill":       {kind: skillField, path: "skills.text"},
+	"salary":      {kind: numberField},
+	"posted":      {kind: dateField, path: "created_at"},
+	"mode": {kind: keywordField, path: "work_mode", values: []string{
+		string(models.WorkModeOnsite), string(models.WorkModeHybrid), string(models.WorkModeRemote),
+	}},
+	"type": {kind: keywordField, path: "employment_type", values: []string{
+		string(models.EmploymentFullTime), string(models.EmploymentPartTime),
+		string(models.EmploymentContract), string(models.EmploymentInternship),
+	}},
+	"seniority": {kind: keywordField, path: "seniority", values: []string{
+		string(models.SeniorityJunior), string(models.SeniorityMid), string(models.SenioritySenior),
+		string(models.SeniorityLead), string(models.SeniorityPrincipal),
+	}},
+}
+
+func fieldNames() string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Translator turns a query tree into an Elasticsearch query.
+type Translator struct {
+	// Text builds the query for free text outside of fields. Exact is set
+	// for quoted phrases and negated words, which should not match fuzzily.
+	Text func(text string, exact bool) map[string]interface{}
+}
+
+// Translate returns the Elasticsearch query for node. Free-text words that
+// are simply listed next to each other are passed to Text together, so a
+// plain query like "golang developer" is scored as a whole.
+func (t Translator) Translate(node Node) map[string]interface{} {
+	return t.translate(node, false)
+}
+
+func (t Translator) translate(node Node, negated bool) map[string]interface{} {
+	switch n := node.(type) {
+	case *And:
+		return t.translateAnd(n, negated)
+	case *Or:
+		should := make([]interface{}, 0, len(n.Clauses))
+		for _, clause := range n.Clauses {
+			should = append(should, t.translate(clause, negated))
+		}
+		return map[string]interface{}{
+			"bool": map[string]interface{}{
+				"should":               should,
+				"minimum_should_match": 1,
+			},
+		}
+	case *Not:
+		return map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must_not": []interface{}{t.translate(n.Clause, !negated)},
+			},
+		}
+	case *Term:
+		return t.translateTerm(n, negated)
+	case *Range:
+		return translateRange(n)
+	default:
+		return map[string]interface{}{"match_all": map[string]interface{}{}}
+	}
+}
+
+func (t Translator) translateAnd(n *And, negated bool) map[string]interface{} {
+	must := []interface{}{}
+	filter := []interface{}{}
+	mustNot := []interface{}{}
+	words := []string{}
+
+	for _, clause := range n.Clauses {
+		switch c := clause.(type) {
+		case *Term:
+			if c.Field == "" && !c.Phrase && !negated {
+				words = append(words, c.Value)
+				continue
+			}
+			if fields[c.Field].kind == keywordField {
+				filter = append(filter, t.translateTerm(c, false))
+				continue
+			}
+			must = append(must, t.translateTerm(c, negated))
+		case *Range:
+			filter = append(filter, translateRange(c))
+		case *Not:
+			mustNot = append(mustNot, t.translate(c.Clause, true))
+		default:
+			must = append(must, t.translate(c, negated))
+		}
+	}
+
+	if len(words) > 0 {
+		must = append([]interface{}{t.Text(strings.Join(words, " "), false)}, must...)
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must":     must,
+			"filter":   filter,
+			"must_not": mustNot,
+		},
+	}
+}
+
+func (t Translator) translateTerm(n *Term, negated bool) map[string]interface{} {
+	if n.Field == "" {
+		return t.Text(n.Value, n.Phrase || negated)
+	}
+
+	f := fields[n.Field]
+	switch f.kind {
+	case skillField:
+		return map[string]interface{}{
+			"match": map[string]interface{}{
+				f.path: n.Value,
+			},
+		}
+	case keywordField:
+		return map[string]interface{}{
+			"term": map[string]interface{}{
+				f.path: n.Value,
+			},
+		}
+	default:
+		if n.Phrase {
+			return map[string]interface{}{
+				"match_phrase": map[string]interface{}{
+					f.path: n.Value,
+				},
+			}
+		}
+		return map[string]interface{}{
+			"match": map[string]interface{}{
+				f.path: map[string]interface{}{
+					"query":    n.Value,
+					"operator": "and",
+				},
+			},
+		}
+	}
+}
+
+// translateRange compares dates by whole days. Salary ranges match jobs whose
+// annual salary range overlaps them, like the min_salary and max_salary
+// filters.
+func translateRange(n *Range) map[string]interface{} {
+	f := fields[n.Field]
+
+	from, to := n.From, n.To
+	if f.kind == dateField {
+		if from != "" {
+			from += "||/d"
+		}
+		if to != "" {
+			to += "||/d"
+		}
+	}
+
+	lower := map[string]interface{}{}
+	if from != "" {
+		lower[op("gt", n.IncludeFrom)] = from
+	}
+	upper := map[string]interface{}{}
+	if to != "" {
+		upper[op("lt", n.IncludeTo)] = to
+	}
+
+	if f.kind != numberField {
+		bounds := lower
+		for key, value := range upper {
+			bounds[key] = value
+		}
+		return map[string]interface{}{
+			"range": map[string]interface{}{
+				f.path: bounds,
+			},
+		}
+	}
+
+	clauses := []interface{}{}
+	if len(lower) > 0 {
+		clauses = append(clauses, map[string]interface{}{
+			"range": map[string]interface{}{"annual_salary_max": lower},
+		})
+	}
+	if len(upper) > 0 {
+		clauses = append(clauses, map[string]interface{}{
+			"range": map[string]interface{}{"annual_salary_min": upper},
+		})
+	}
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"filter": clauses,
+		},
+	}
+}
+
+func op(base string, inclusive bool) string {
+	if inclusive {
+		return base + "e"
+	}
+	return base
+}
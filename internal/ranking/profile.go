@@ -0,0 +1,162 @@
+package ranking
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var ErrUnknownProfile = errors.New("unknown ranking profile")
+
+// Profile describes how search results are scored on top of the text match.
+type Profile struct {
+	// FieldBoosts weights the free-text fields title, description and
+	// company.
+	FieldBoosts map[string]float64 `yaml:"field_boosts"`
+	// Recency lowers the score of older postings.
+	Recency *Decay `yaml:"recency"`
+	// Salary raises the score of better paid jobs.
+	Salary *SalaryBoost `yaml:"salary"`
+	// FeaturedBoost multiplies the score of featured jobs; 0 or 1 disables
+	// it.
+	FeaturedBoost float64 `yaml:"featured_boost"`
+}
+
+// Decay is an Elasticsearch decay function on created_at: postings younger
+// than Offset keep their full score, postings Offset+Scale old get Decay
+// times it.
+type Decay struct {
+	// Function is gauss, exp or linear.
+	Function string  `yaml:"function"`
+	Scale    string  `yaml:"scale"`
+	Offset   string  `yaml:"offset"`
+	Decay    float64 `yaml:"decay"`
+}
+
+// SalaryBoost multiplies the score by 1 + Weight * ln(1 + salary / Scale),
+// using the top of the annual salary range. Jobs without a salary keep their
+// score.
+type SalaryBoost struct {
+	Weight float64 `yaml:"weight"`
+	Scale  float64 `yaml:"scale"`
+}
+
+// TextFields are the free-text fields a profile may boost.
+var TextFields = []string{"title", "description", "company"}
+
+// Legacy is used when no profiles are configured. It keeps the field boosts
+// from before ranking profiles existed and adds nothing else.
+var Legacy = Profile{
+	FieldBoosts: map[string]float64{"title": 2, "description": 1, "company": 1.5},
+}
+
+// Config holds the named profiles from the ranking section of the config
+// file.
+type Config struct {
+	Default  string             `yaml:"default"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// Profile returns the named profile, or the default one for an empty name.
+func (c Config) Profile(name string) (*Profile, error) {
+	if len(c.Profiles) == 0 && name == "" {
+		profile := Legacy
+		return &profile, nil
+	}
+	if name == "" {
+		name = c.Default
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q, expected one of %s", ErrUnknownProfile, name, strings.Join(c.names(), ", "))
+	}
+	return &profile, nil
+}
+
+func (c Config) names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks the profiles when the server starts, so that a typo in the
+// config does not surface as a failing search.
+func (c Config) Validate() error {
+	if len(c.Profiles) == 0 {
+		return nil
+	}
+	if _, ok := c.Profiles[c.Default]; !ok {
+		return fmt.Errorf("default ranking profile %q is not defined", c.Default)
+	}
+
+	for _, name := range c.names() {
+		if err := c.Profiles[name].validate(); err != nil {
+			return fmt.Errorf("ranking profile %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (p Profile) validate() error {
+	if len(p.Fields()) == 0 {
+		return fmt.Errorf("field_boosts must leave at least one field with a positive boost")
+	}
+	for field, boost := range p.FieldBoosts {
+		known := false
+		for _, name := range TextFields {
+			known = known || name == field
+		}
+		if !known {
+			return fmt.Errorf("unknown field %q in field_boosts, expected one of %s", field, strings.Join(TextFields, ", "))
+		}
+		if boost < 0 {
+			return fmt.Errorf("boost of %s must not be negative", field)
+		}
+	}
+
+	if decay := p.Recency; decay != nil {
+		switch decay.Function {
+		case "gauss", "exp", "linear":
+		default:
+			return fmt.Errorf("recency function must be gauss, exp or linear, got %q", decay.Function)
+		}
+		if decay.Scale == "" {
+			return fmt.Errorf("recency scale is required")
+		}
+		if decay.Decay <= 0 || decay.Decay >= 1 {
+			return fmt.Errorf("recency decay must be between 0 and 1")
+		}
+	}
+
+	if salary := p.Salary; salary != nil && (salary.Weight < 0 || salary.Scale <= 0) {
+		return fmt.Errorf("salary weight must not be negative and scale must be positive")
+	}
+
+	if p.FeaturedBoost < 0 {
+		return fmt.Errorf("featured_boost must not be negative")
+	}
+
+	return nil
+}
+
+// Fields returns the free-text fields with their boosts in Elasticsearch
+// notation, e.g. "title^2". Fields without a boost default to 1 and fields
+// boosted with 0 are left out.
+func (p Profile) Fields() []string {
+	fields := make([]string, 0, len(TextFields))
+	for _, field := range TextFields {
+		boost, ok := p.FieldBoosts[field]
+		switch {
+		case !ok || boost == 1:
+			fields = append(fields, field)
+		case boost > 0:
+			fields = append(fields, fmt.Sprintf("%s^%g", field, boost))
+		}
+	}
+	return fields
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"job-search-service/internal/models"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// bulkResponse is the part of a _bulk response CreateBatch reads. Items are
+// in request order.
+type bulkResponse struct {
+	Items []map[string]bulkItem `json:"items"`
+}
+
+type bulkItem struct {
+	writeResponse
+	Status int `json:"status"`
+	Error  *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
+}
+
+// CreateBatch indexes jobs with a single _bulk request without a refresh.
+func (r *JobRepository) CreateBatch(ctx context.Context, jobs []*models.Job) ([]error, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, job := range jobs {
+		action := map[string]interface{}{
+			"create": map[string]interface{}{"_id": job.ID},
+		}
+		if err := encoder.Encode(action); err != nil {
+			return nil, fmt.Errorf("error encoding bulk action: %w", err)
+		}
+		if err := encoder.Encode(job); err != nil {
+			return nil, fmt.Errorf("error marshaling job: %w", err)
+		}
+	}
+
+	req := esapi.BulkRequest{
+		Index: r.indexName,
+		Body:  &buf,
+	}
+
+	res, err := req.Do(ctx, r.client)
+	if err != nil {
+		return nil, requestError("error indexing documents", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, responseError("error indexing documents", res)
+	}
+
+	var bulk bulkResponse
+	if err := json.NewDecoder(res.Body).Decode(&bulk); err != nil {
+		return nil, fmt.Errorf("error parsing response: %w", err)
+	}
+	if len(bulk.Items) != len(jobs) {
+		return nil, fmt.Errorf("bulk response has %d items for %d jobs", len(bulk.Items), len(jobs))
+	}
+
+	failures := make([]error, len(jobs))
+	for i, job := range jobs {
+		item := bulk.Items[i]["create"]
+		if item.Error == nil {
+			job.Version = formatVersion(indexGeneration(item.Index), item.SeqNo, item.PrimaryTerm)
+			continue
+		}
+
+		err := fmt.Errorf("error indexing job %s: %s: %s", job.ID, item.Error.Type, item.Error.Reason)
+		if item.Status == http.StatusConflict {
+			err = fmt.Errorf("job %s already exists: %w", job.ID, ErrVersionConflict)
+		}
+		failures[i] = unavailableError(item.Status, item.Error.Type, err)
+	}
+
+	return failures, nil
+}
+
+func (r *JobRepository) Refresh(ctx context.Context) error {
+	req := esapi.IndicesRefreshRequest{
+		Index: []string{r.indexName},
+	}
+
+	res, err := req.Do(ctx, r.client)
+	if err != nil {
+		return requestError("error refreshing index", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return responseError("error refreshing index", res)
+	}
+	return nil
+}
@@ -5,7 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"job-search-service/internal/geo"
 	"job-search-service/internal/models"
+	"job-search-service/internal/querylang"
+	"log"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
@@ -33,172 +39,289 @@ func (r *JobRepository) Create(ctx context.Context, job *models.Job) error {
 		Index:      r.indexName,
 		DocumentID: job.ID,
 		Body:       bytes.NewReader(data),
+		OpType:     "create",
 		Refresh:    "true",
 	}
 
 	res, err := req.Do(ctx, r.client)
 	if err != nil {
-		return fmt.Errorf("error indexing document: %w", err)
+		return requestError("error indexing document", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return fmt.Errorf("error indexing document: %s", res.String())
+		if res.StatusCode == 409 {
+			return fmt.Errorf("job %s already exists: %w", job.ID, ErrVersionConflict)
+		}
+		return responseError("error indexing document", res)
+	}
+
+	var written writeResponse
+	if err := json.NewDecoder(res.Body).Decode(&written); err != nil {
+		return fmt.Errorf("error parsing response: %w", err)
 	}
+	job.Version = formatVersion(indexGeneration(written.Index), written.SeqNo, written.PrimaryTerm)
 
 	return nil
 }
 
-func (r *JobRepository) Search(ctx context.Context, query string, location string, skills []string) ([]*models.Job, error) {
+// Search returns one page of jobs matching params. When there is a next page
+// a point-in-time is opened so that later pages, requested with the returned
+// NextPageToken, see the same snapshot of the index. The first page itself is
+// served from the live index, so searches that fit on one page never hold a
+// search context.
+func (r *JobRepository) Search(ctx context.Context, params models.SearchParams) (*models.SearchResult, error) {
 	var buf bytes.Buffer
 
+	// Limits are enforced by the service.
+	parsed, err := querylang.Parse(params.Query, querylang.Limits{})
+	if err != nil {
+		return nil, err
+	}
+
+	var token pageToken
+	if params.PageToken != "" {
+		if token, err = decodePageToken(params.PageToken, params); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now().Truncate(time.Millisecond)
+	if token.Now != 0 {
+		now = time.UnixMilli(token.Now)
+	}
+
+	query, postFilter, aggs := buildQuery(params, parsed, now)
 	searchQuery := map[string]interface{}{
-		"query": map[string]interface{}{
-			"bool": map[string]interface{}{
-				"must": []interface{}{},
-			},
-		},
-	}
-
-	mustQueries := []interface{}{}
-
-	if query != "" {
-		mustQueries = append(mustQueries, map[string]interface{}{
-			"bool": map[string]interface{}{
-				"should": []interface{}{
-					map[string]interface{}{
-						"multi_match": map[string]interface{}{
-							"query":     query,
-							"fields":    []string{"title^2", "description", "company^1.5"},
-							"fuzziness": "AUTO",
-						},
-					},
-					map[string]interface{}{
-						"wildcard": map[string]interface{}{
-							"title.keyword": map[string]interface{}{
-								"value":            "*" + query + "*",
-								"case_insensitive": true,
-							},
-						},
-					},
-					map[string]interface{}{
-						"wildcard": map[string]interface{}{
-							"company.keyword": map[string]interface{}{
-								"value":            "*" + query + "*",
-								"case_insensitive": true,
-							},
-						},
-					},
-					map[string]interface{}{
-						"wildcard": map[string]interface{}{
-							"description.keyword": map[string]interface{}{
-								"value":            "*" + query + "*",
-								"case_insensitive": true,
-							},
-						},
-					},
-				},
-				"minimum_should_match": 1,
-			},
-		})
-	}
-
-	if location != "" {
-		mustQueries = append(mustQueries, map[string]interface{}{
-			"bool": map[string]interface{}{
-				"should": []interface{}{
-					map[string]interface{}{
-						"match": map[string]interface{}{
-							"location": location,
-						},
-					},
-					map[string]interface{}{
-						"wildcard": map[string]interface{}{
-							"location.keyword": map[string]interface{}{
-								"value":            "*" + location + "*",
-								"case_insensitive": true,
-							},
-						},
-					},
-				},
-				"minimum_should_match": 1,
-			},
-		})
-	}
-
-	if len(skills) > 0 {
-		mustQueries = append(mustQueries, map[string]interface{}{
-			"terms": map[string]interface{}{
-				"skills": skills,
-			},
-		})
-	}
-
-	if len(mustQueries) == 0 {
-		searchQuery = map[string]interface{}{
-			"query": map[string]interface{}{
-				"match_all": map[string]interface{}{},
-			},
-		}
-	} else {
-		searchQuery["query"].(map[string]interface{})["bool"].(map[string]interface{})["must"] = mustQueries
+		"query": query,
+	}
+	if postFilter != nil {
+		searchQuery["post_filter"] = postFilter
+	}
+	if aggs != nil {
+		searchQuery["aggs"] = aggs
+	}
+	if params.Highlight != nil {
+		searchQuery["highlight"] = buildHighlight(params.Highlight)
+	}
+
+	searchQuery["size"] = params.PageSize
+	var origin *models.GeoPoint
+	if params.Near != nil {
+		origin = params.Near.Origin
+	}
+	sort := buildSort(params.Sort, origin)
+	if len(token.SearchAfter) > 0 && len(token.SearchAfter) != len(sort) {
+		return nil, fmt.Errorf("%w: sort order changed between pages", ErrInvalidPageToken)
 	}
 
+	searchQuery["sort"] = sort
+	if token.PitID != "" {
+		searchQuery["pit"] = map[string]interface{}{
+			"id":         token.PitID,
+			"keep_alive": pitKeepAlive,
+		}
+		searchQuery["search_after"] = token.SearchAfter
+	}
+	searchQuery["track_total_hits"] = true
+	// Scores are returned even when sorting by another field.
+	searchQuery["track_scores"] = true
+	if params.Explain {
+		searchQuery["explain"] = true
+	}
+	searchQuery["seq_no_primary_term"] = true
+
 	if err := json.NewEncoder(&buf).Encode(searchQuery); err != nil {
 		return nil, fmt.Errorf("error encoding query: %w", err)
 	}
 
-	res, err := r.client.Search(
+	options := []func(*esapi.SearchRequest){
 		r.client.Search.WithContext(ctx),
-		r.client.Search.WithIndex(r.indexName),
 		r.client.Search.WithBody(&buf),
-		r.client.Search.WithTrackTotalHits(true),
-	)
+	}
+	// A point-in-time already names the index it was opened on.
+	if token.PitID == "" {
+		options = append(options, r.client.Search.WithIndex(r.indexName))
+	}
+
+	res, err := r.client.Search(options...)
 	if err != nil {
-		return nil, fmt.Errorf("error executing search: %w", err)
+		return nil, requestError("error executing search", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return nil, fmt.Errorf("error response: %s", res.String())
+		if params.PageToken != "" && res.StatusCode == 404 {
+			return nil, fmt.Errorf("%w: page token has expired", ErrInvalidPageToken)
+		}
+		return nil, responseError("error executing search", res)
 	}
 
-	var result map[string]interface{}
+	var result searchResponse
 	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
 		return nil, fmt.Errorf("error parsing response body: %w", err)
 	}
 
-	hits := result["hits"].(map[string]interface{})["hits"].([]interface{})
+	hits := result.Hits.Hits
 	jobs := make([]*models.Job, 0, len(hits))
 
 	for _, hit := range hits {
-		source := hit.(map[string]interface{})["_source"].(map[string]interface{})
-		jobData, _ := json.Marshal(source)
 		var job models.Job
-		if err := json.Unmarshal(jobData, &job); err != nil {
+		if err := json.Unmarshal(hit.Source, &job); err != nil {
 			continue
 		}
-		if score, ok := hit.(map[string]interface{})["_score"].(float64); ok {
-			job.Score = score
+		if hit.Score != nil {
+			job.Score = *hit.Score
+		}
+		job.Version = formatVersion(indexGeneration(hit.Index), hit.SeqNo, hit.PrimaryTerm)
+		job.Highlights = hit.Highlight
+		job.MatchedSkills = matchedSkills(params.Skills, hit.MatchedQueries)
+		job.Explanation = hit.Explanation
+		if origin != nil && job.Geo != nil {
+			job.DistanceKm = geo.DistanceKm(*origin, *job.Geo)
 		}
 		jobs = append(jobs, &job)
 	}
 
-	return jobs, nil
+	searchResult := &models.SearchResult{
+		Jobs:   jobs,
+		Total:  result.Hits.Total.Value,
+		Facets: parseFacets(result.Aggregations),
+	}
+
+	// Only plain text is corrected, since a suggestion replaces the whole
+	// query.
+	if text, ok := querylang.PlainText(parsed); ok && searchResult.Total == 0 && params.PageToken == "" {
+		// A spelling suggestion is only a hint, so a failure here should not
+		// fail the search itself.
+		searchResult.SuggestedQuery, err = r.suggestQuery(ctx, text)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	if len(hits) < params.PageSize || (token.PitID == "" && searchResult.Total <= int64(len(hits))) {
+		if token.PitID != "" {
+			if err := r.closePointInTime(ctx, result.PitID); err != nil {
+				log.Printf("Warning: %v", err)
+			}
+		}
+		return searchResult, nil
+	}
+
+	next := pageToken{
+		PitID:       result.PitID,
+		SearchAfter: hits[len(hits)-1].Sort,
+		Now:         now.UnixMilli(),
+	}
+	if token.PitID == "" {
+		// Writes that land between this page and opening the
+		// point-in-time are visible to the later pages.
+		if next.PitID, err = r.openPointInTime(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if next.Query, err = searchHash(params); err != nil {
+		return nil, err
+	}
+	searchResult.NextPageToken, err = encodePageToken(next)
+	if err != nil {
+		return nil, err
+	}
+
+	return searchResult, nil
+}
+
+type searchResponse struct {
+	PitID string `json:"pit_id"`
+	Hits  struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		Hits []searchHit `json:"hits"`
+	} `json:"hits"`
+	Aggregations map[string]facetAggregationResult `json:"aggregations"`
+}
+
+type facetAggregationResult struct {
+	Values struct {
+		Buckets []struct {
+			Key         json.RawMessage `json:"key"`
+			KeyAsString string          `json:"key_as_string"`
+			DocCount    int64           `json:"doc_count"`
+		} `json:"buckets"`
+	} `json:"values"`
+}
+
+func parseFacets(aggregations map[string]facetAggregationResult) map[models.FacetField][]models.FacetBucket {
+	if len(aggregations) == 0 {
+		return nil
+	}
+
+	facets := make(map[models.FacetField][]models.FacetBucket, len(aggregations))
+	for name, aggregation := range aggregations {
+		field, ok := strings.CutPrefix(name, facetAggregationPrefix)
+		if !ok {
+			continue
+		}
+
+		buckets := make([]models.FacetBucket, 0, len(aggregation.Values.Buckets))
+		for _, bucket := range aggregation.Values.Buckets {
+			key := bucket.KeyAsString
+			if key == "" {
+				key = bucketKey(bucket.Key)
+			}
+			buckets = append(buckets, models.FacetBucket{
+				Key:   key,
+				Count: bucket.DocCount,
+			})
+		}
+		facets[models.FacetField(field)] = buckets
+	}
+
+	return facets
+}
+
+// bucketKey renders a terms key as-is and a histogram key without a trailing
+// ".0".
+func bucketKey(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+
+	var number float64
+	if err := json.Unmarshal(raw, &number); err == nil {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+
+	return string(raw)
+}
+
+type searchHit struct {
+	Index       string              `json:"_index"`
+	Source      json.RawMessage     `json:"_source"`
+	Score       *float64            `json:"_score"`
+	SeqNo       int64               `json:"_seq_no"`
+	PrimaryTerm int64               `json:"_primary_term"`
+	Sort        []json.RawMessage   `json:"sort"`
+	Highlight   map[string][]string `json:"highlight"`
+	// MatchedQueries holds the names of the named queries the hit matched.
+	MatchedQueries []string            `json:"matched_queries"`
+	Explanation    *models.Explanation `json:"_explanation"`
 }
 
 func (r *JobRepository) GetByID(ctx context.Context, id string) (*models.Job, error) {
-	res, err := r.client.Get(r.indexName, id)
+	res, err := r.client.Get(r.indexName, id, r.client.Get.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("error getting document: %w", err)
+		return nil, requestError("error getting document", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
 		if res.StatusCode == 404 {
-			return nil, fmt.Errorf("job not found")
+			return nil, jobNotFound(id)
 		}
-		return nil, fmt.Errorf("error getting document: %s", res.String())
+		return nil, responseError("error getting document", res)
 	}
 
 	var result map[string]interface{}
@@ -212,29 +335,135 @@ func (r *JobRepository) GetByID(ctx context.Context, id string) (*models.Job, er
 	if err := json.Unmarshal(jobData, &job); err != nil {
 		return nil, fmt.Errorf("error unmarshaling job: %w", err)
 	}
+	index, _ := result["_index"].(string)
+	seqNo, _ := result["_seq_no"].(float64)
+	primaryTerm, _ := result["_primary_term"].(float64)
+	job.Version = formatVersion(indexGeneration(index), int64(seqNo), int64(primaryTerm))
 
 	return &job, nil
 }
 
-func (r *JobRepository) Delete(ctx context.Context, id string) error {
+// Update writes job over the stored document. The whole document is indexed
+// rather than merged, so fields the job no longer has are removed. When
+// expectedVersion is set the write only succeeds if the document has not
+// changed since that version was read; otherwise ErrVersionConflict is
+// returned, also when the version was read from an index a migration has
+// since replaced. Callers read the job with GetByID first, which reports
+// missing jobs.
+func (r *JobRepository) Update(ctx context.Context, job *models.Job, expectedVersion string) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("error marshaling job: %w", err)
+	}
+
+	req := esapi.IndexRequest{
+		Index:      r.indexName,
+		DocumentID: job.ID,
+		Body:       bytes.NewReader(data),
+		Refresh:    "true",
+	}
+	if expectedVersion != "" {
+		index, seqNo, primaryTerm, err := r.versionedIndex(ctx, job.ID, expectedVersion)
+		if err != nil {
+			return err
+		}
+		req.Index = index
+		req.IfSeqNo = &seqNo
+		req.IfPrimaryTerm = &primaryTerm
+	}
+
+	res, err := req.Do(ctx, r.client)
+	if err != nil {
+		return requestError("error updating document", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		// A job deleted since it was read fails the seq_no check as well.
+		if res.StatusCode == 409 {
+			return fmt.Errorf("job %s: %w", job.ID, ErrVersionConflict)
+		}
+		return responseError("error updating document", res)
+	}
+
+	var written writeResponse
+	if err := json.NewDecoder(res.Body).Decode(&written); err != nil {
+		return fmt.Errorf("error parsing response: %w", err)
+	}
+	job.Version = formatVersion(indexGeneration(written.Index), written.SeqNo, written.PrimaryTerm)
+
+	return nil
+}
+
+func (r *JobRepository) Delete(ctx context.Context, id string, expectedVersion string) error {
 	req := esapi.DeleteRequest{
 		Index:      r.indexName,
 		DocumentID: id,
 		Refresh:    "true",
 	}
+	if expectedVersion != "" {
+		index, seqNo, primaryTerm, err := r.versionedIndex(ctx, id, expectedVersion)
+		if err != nil {
+			return err
+		}
+		req.Index = index
+		req.IfSeqNo = &seqNo
+		req.IfPrimaryTerm = &primaryTerm
+	}
 
 	res, err := req.Do(ctx, r.client)
 	if err != nil {
-		return fmt.Errorf("error deleting document: %w", err)
+		return requestError("error deleting document", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
 		if res.StatusCode == 404 {
-			return fmt.Errorf("job not found")
+			return jobNotFound(id)
+		}
+		if res.StatusCode == 409 {
+			return fmt.Errorf("job %s: %w", id, ErrVersionConflict)
 		}
-		return fmt.Errorf("error deleting document: %s", res.String())
+		return responseError("error deleting document", res)
 	}
 
 	return nil
 }
+
+// versionedIndex returns the physical index holding job id and the seq_no
+// and primary term of expectedVersion, or ErrVersionConflict when the version
+// was issued by another index generation. Conditional writes go to that
+// index rather than the alias: if a migration swaps the alias before the
+// write arrives, the write fails on the old index's write block and is
+// retried, instead of matching a seq_no in the new index by chance.
+func (r *JobRepository) versionedIndex(ctx context.Context, id, expectedVersion string) (string, int, int, error) {
+	generation, seqNo, primaryTerm, err := parseVersion(expectedVersion)
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	res, err := r.client.Get(r.indexName, id,
+		r.client.Get.WithContext(ctx),
+		r.client.Get.WithSource("false"),
+	)
+	if err != nil {
+		return "", 0, 0, requestError("error getting document", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return "", 0, 0, jobNotFound(id)
+		}
+		return "", 0, 0, responseError("error getting document", res)
+	}
+
+	var current writeResponse
+	if err := json.NewDecoder(res.Body).Decode(&current); err != nil {
+		return "", 0, 0, fmt.Errorf("error parsing response: %w", err)
+	}
+	if indexGeneration(current.Index) != int64(generation) {
+		return "", 0, 0, fmt.Errorf("job %s: %w: version is from an index replaced by a migration", id, ErrVersionConflict)
+	}
+	return current.Index, seqNo, primaryTerm, nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"job-search-service/internal/errs"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// ErrJobNotFound matches the errors returned for missing jobs with
+// errors.Is.
+var ErrJobNotFound = &errs.Error{Kind: errs.NotFound, Reason: "JOB_NOT_FOUND", Msg: "job not found"}
+
+func jobNotFound(id string) error {
+	return &errs.Error{
+		Kind:     errs.NotFound,
+		Reason:   ErrJobNotFound.Reason,
+		Msg:      fmt.Sprintf("job %s not found", id),
+		Metadata: map[string]string{"job_id": id},
+	}
+}
+
+// requestError wraps a failure to talk to Elasticsearch at all.
+func requestError(op string, err error) error {
+	return errs.Wrap(errs.Unavailable, "BACKEND_UNAVAILABLE", "", fmt.Errorf("%s: %w", op, err))
+}
+
+// responseError wraps an error response. Overload and gateway errors are
+// reported as unavailable so that clients retry them, and so are writes
+// rejected by an index block, such as the one a migration sets while it
+// catches up; anything else is a bug or a broken index and is returned as is.
+func responseError(op string, res *esapi.Response) error {
+	body, _ := io.ReadAll(res.Body)
+	err := fmt.Errorf("%s: [%s] %s", op, res.Status(), body)
+
+	var response errorResponse
+	json.Unmarshal(body, &response)
+	return unavailableError(res.StatusCode, response.Error.Type, err)
+}
+
+// errorResponse is the part of an error response responseError reads.
+type errorResponse struct {
+	Error struct {
+		Type string `json:"type"`
+	} `json:"error"`
+}
+
+// unavailableError marks err as unavailable when the status and error type
+// of a failed request are worth retrying.
+func unavailableError(status int, errorType string, err error) error {
+	switch {
+	case status == http.StatusForbidden && errorType == "cluster_block_exception":
+		return errs.Wrap(errs.Unavailable, "WRITES_BLOCKED", "", err)
+	case status == http.StatusTooManyRequests, status == http.StatusBadGateway,
+		status == http.StatusServiceUnavailable, status == http.StatusGatewayTimeout:
+		return errs.Wrap(errs.Unavailable, "BACKEND_UNAVAILABLE", "", err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"errors"
+	"io"
+	"job-search-service/internal/errs"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+func TestResponseError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		kind   errs.Kind
+		reason string
+	}{
+		{
+			name:   "write block during a migration",
+			status: http.StatusForbidden,
+			body:   `{"error":{"type":"cluster_block_exception","reason":"index [jobs_v1] blocked by: [FORBIDDEN/8/index write (api)];"},"status":403}`,
+			kind:   errs.Unavailable,
+			reason: "WRITES_BLOCKED",
+		},
+		{
+			name:   "other forbidden request",
+			status: http.StatusForbidden,
+			body:   `{"error":{"type":"security_exception"},"status":403}`,
+			kind:   errs.Internal,
+		},
+		{
+			name:   "overload",
+			status: http.StatusTooManyRequests,
+			body:   `{"error":{"type":"es_rejected_execution_exception"},"status":429}`,
+			kind:   errs.Unavailable,
+			reason: "BACKEND_UNAVAILABLE",
+		},
+		{
+			name:   "gateway error without a JSON body",
+			status: http.StatusBadGateway,
+			body:   "Bad Gateway",
+			kind:   errs.Unavailable,
+			reason: "BACKEND_UNAVAILABLE",
+		},
+		{
+			name:   "bad request",
+			status: http.StatusBadRequest,
+			body:   `{"error":{"type":"parsing_exception"},"status":400}`,
+			kind:   errs.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := responseError("error indexing document", &esapi.Response{
+				StatusCode: tt.status,
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			})
+
+			var domainErr *errs.Error
+			kind, reason := errs.Internal, ""
+			if errors.As(err, &domainErr) {
+				kind, reason = domainErr.Kind, domainErr.Reason
+			}
+			if kind != tt.kind || reason != tt.reason {
+				t.Errorf("error = %v (%s, %q), want %s, %q", err, kind, reason, tt.kind, tt.reason)
+			}
+			if !strings.Contains(err.Error(), tt.body) {
+				t.Errorf("error = %v, want it to include the response body", err)
+			}
+		})
+	}
+}
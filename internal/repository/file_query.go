@@ -0,0 +1,558 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"job-search-service/internal/models"
+	"job-search-service/internal/querylang"
+	"job-search-service/internal/taxonomy"
+	"strconv"
+	"strings"
+)
+
+// maxMatchTerms caps the terms of one full-text query; longer expansions are
+// split over several.
+const maxMatchTerms = 200
+
+// indexColumns numbers the columns of job_index as job_terms does.
+var indexColumns = map[string]int{
+	"title":       0,
+	"description": 1,
+	"company":     2,
+	"location":    3,
+}
+
+// substringColumns and locationColumn are bit masks of indexColumns.
+// substringColumns are the columns free words match substrings of, like the
+// trigram subfields.
+const (
+	substringColumns = 1<<0 | 1<<2
+	locationColumn   = 1 << 3
+)
+
+// indexTerm is a term of the full-text index.
+type indexTerm struct {
+	// raw is the term as the index stores it and words as tokenize reads it.
+	raw   string
+	words []string
+	// columns has bit i set if column i of job_index uses the term.
+	columns int
+}
+
+// fileQuery translates a search into an SQL condition selecting the jobs that
+// can match it, through the full-text index where it searches text. The
+// condition also selects some jobs that do not match, which searchStored
+// filters out, but never misses one that does.
+type fileQuery struct {
+	ctx     context.Context
+	tx      *sql.Tx
+	skills  *taxonomy.Taxonomy
+	matcher *memoryMatcher
+	args    []interface{}
+	// terms and storedSkills are the index vocabulary and the distinct
+	// skills of all jobs, read on first use.
+	terms        []indexTerm
+	storedSkills []string
+	// err is the first error reading the store.
+	err error
+}
+
+// Search looks up the jobs that can match params in the index and evaluates
+// params on them like MemoryJobRepository.
+func (r *FileJobRepository) Search(ctx context.Context, params models.SearchParams) (*models.SearchResult, error) {
+	parsed, err := querylang.Parse(params.Query, querylang.Limits{})
+	if err != nil {
+		return nil, err
+	}
+
+	offset := 0
+	if params.PageToken != "" {
+		if offset, err = decodeMemoryPageToken(params.PageToken, params); err != nil {
+			return nil, err
+		}
+	}
+
+	// The transaction reads the vocabulary and the jobs from one snapshot.
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	q := &fileQuery{ctx: ctx, tx: tx, skills: r.skills, matcher: newMemoryMatcher(params, r.skills)}
+	where := q.build(parsed)
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT j.seq_no, j.doc FROM jobs j WHERE `+where, q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("error searching jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []storedJob{}
+	for rows.Next() {
+		var (
+			seqNo int64
+			doc   string
+		)
+		if err := rows.Scan(&seqNo, &doc); err != nil {
+			return nil, fmt.Errorf("error searching jobs: %w", err)
+		}
+		stored, err := decodeStoredJob(seqNo, doc)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, stored)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error searching jobs: %w", err)
+	}
+
+	return searchStored(params, parsed, q.matcher, offset, jobs)
+}
+
+// build returns the condition for the query and the filters. Filters whose
+// facet is requested are left out, since the facet counts jobs they reject.
+// It also lists the corrections of misspelled locations and skills in the
+// matcher.
+func (q *fileQuery) build(parsed querylang.Node) string {
+	params := q.matcher.params
+	clauses := []string{}
+
+	if parsed != nil {
+		clauses = append(clauses, q.node(parsed))
+	}
+
+	if params.Location != "" {
+		terms := q.resolveLocation(params.Location)
+		if !facetRequested(params.Facets, models.FacetLocation) {
+			clauses = append(clauses, "("+q.match("location", terms)+" OR instr(j.location_lower, ?) > 0)")
+			q.args = append(q.args, strings.ToLower(strings.TrimSpace(params.Location)))
+		}
+	}
+
+	if len(params.Skills) > 0 {
+		accepted := make([][]string, len(params.Skills))
+		for i, skill := range params.Skills {
+			accepted[i] = q.resolveSkill(skill)
+		}
+		if !facetRequested(params.Facets, models.FacetSkills) {
+			counts := make([]string, len(accepted))
+			for i, names := range accepted {
+				counts[i] = q.hasSkill(names)
+			}
+			clauses = append(clauses, "("+strings.Join(counts, " + ")+") >= ?")
+			q.args = append(q.args, skillsMinimumMatch(params))
+		}
+	}
+
+	if (params.MinSalary != nil || params.MaxSalary != nil) && !facetRequested(params.Facets, models.FacetSalary) {
+		clauses = append(clauses, "NOT (j.annual_salary_min = 0 AND j.annual_salary_max = 0)")
+		if params.MinSalary != nil {
+			clauses = append(clauses, "j.annual_salary_max >= ?")
+			q.args = append(q.args, *params.MinSalary)
+		}
+		if params.MaxSalary != nil {
+			clauses = append(clauses, "j.annual_salary_min <= ?")
+			q.args = append(q.args, *params.MaxSalary)
+		}
+	}
+
+	if len(params.EmploymentTypes) > 0 && !facetRequested(params.Facets, models.FacetEmploymentType) {
+		clauses = append(clauses, in(q, "j.employment_type", params.EmploymentTypes))
+	}
+	if len(params.Seniorities) > 0 && !facetRequested(params.Facets, models.FacetSeniority) {
+		clauses = append(clauses, in(q, "j.seniority", params.Seniorities))
+	}
+	if len(params.WorkModes) > 0 {
+		clauses = append(clauses, in(q, "j.work_mode", params.WorkModes))
+	}
+
+	if !facetRequested(params.Facets, models.FacetCreatedAt) {
+		if !params.PostedAfter.IsZero() {
+			clauses = append(clauses, "j.created_at >= ?")
+			q.args = append(q.args, params.PostedAfter.UnixNano())
+		}
+		if !params.PostedBefore.IsZero() {
+			clauses = append(clauses, "j.created_at < ?")
+			q.args = append(q.args, params.PostedBefore.UnixNano())
+		}
+	}
+
+	if len(clauses) == 0 {
+		return "1"
+	}
+	return strings.Join(clauses, " AND ")
+}
+
+// node mirrors memoryMatcher.match.
+func (q *fileQuery) node(node querylang.Node) string {
+	switch n := node.(type) {
+	case *querylang.And:
+		clauses := []string{}
+		words := []string{}
+		for _, clause := range n.Clauses {
+			if term, ok := clause.(*querylang.Term); ok && term.Field == "" && !term.Phrase {
+				words = append(words, term.Value)
+				continue
+			}
+			clauses = append(clauses, q.node(clause))
+		}
+		if len(words) > 0 {
+			clauses = append(clauses, q.text(strings.Join(words, " ")))
+		}
+		if len(clauses) == 0 {
+			return "1"
+		}
+		return "(" + strings.Join(clauses, " AND ") + ")"
+	case *querylang.Or:
+		clauses := make([]string, len(n.Clauses))
+		for i, clause := range n.Clauses {
+			clauses[i] = q.node(clause)
+		}
+		return "(" + strings.Join(clauses, " OR ") + ")"
+	case *querylang.Term:
+		return q.term(n)
+	default:
+		// Negations and ranges do not narrow the selection; searchStored
+		// evaluates them.
+		return "1"
+	}
+}
+
+// term mirrors memoryMatcher.matchTerm.
+func (q *fileQuery) term(n *querylang.Term) string {
+	switch n.Field {
+	case "":
+		if n.Phrase {
+			return q.phrase(n.Value)
+		}
+		return q.text(n.Value)
+	case "skills", "skill":
+		return q.hasSkill(q.resolveSkill(n.Value))
+	case "mode":
+		q.args = append(q.args, n.Value)
+		return "j.work_mode = ?"
+	case "type":
+		q.args = append(q.args, n.Value)
+		return "j.employment_type = ?"
+	case "seniority":
+		q.args = append(q.args, n.Value)
+		return "j.seniority = ?"
+	}
+
+	column, ok := indexColumns[n.Field]
+	if !ok {
+		return "1"
+	}
+	if n.Phrase {
+		return q.phrase(n.Value)
+	}
+
+	clauses := []string{}
+	for _, word := range tokenize(n.Value) {
+		terms := []string{}
+		for _, term := range q.vocabulary() {
+			if term.columns&(1<<column) != 0 && contains(term.words, word) {
+				terms = append(terms, term.raw)
+			}
+		}
+		clauses = append(clauses, q.match(n.Field, terms))
+	}
+	if len(clauses) == 0 {
+		return "1"
+	}
+	return "(" + strings.Join(clauses, " AND ") + ")"
+}
+
+// text selects the jobs with any index term that one of the words of text
+// matches like in matchText: exactly, misspelled or as a substring.
+func (q *fileQuery) text(text string) string {
+	words := tokenize(text)
+	terms := []string{}
+	for _, term := range q.vocabulary() {
+		if matchesAny(term, words) {
+			terms = append(terms, term.raw)
+		}
+	}
+	return q.match("", terms)
+}
+
+func matchesAny(term indexTerm, words []string) bool {
+	for _, word := range words {
+		if term.columns&substringColumns != 0 && len(word) >= 3 && strings.Contains(term.raw, word) {
+			return true
+		}
+		for _, w := range term.words {
+			if fuzzyMatch(w, word) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// phrase selects the jobs with the words of text in a row. Each word is a
+// prefix since tokenize drops plural endings the index keeps.
+func (q *fileQuery) phrase(text string) string {
+	words := tokenize(text)
+	if len(words) == 0 {
+		return "0"
+	}
+	q.args = append(q.args, `"`+strings.Join(words, "* ")+`*"`)
+	return "j.rowid IN (SELECT docid FROM job_index WHERE job_index MATCH ?)"
+}
+
+// match selects the jobs with any of terms in column, or in any column if
+// column is empty.
+func (q *fileQuery) match(column string, terms []string) string {
+	if len(terms) == 0 {
+		return "0"
+	}
+
+	clauses := []string{}
+	for start := 0; start < len(terms); start += maxMatchTerms {
+		chunk := terms[start:min(start+maxMatchTerms, len(terms))]
+		expr := make([]string, len(chunk))
+		for i, term := range chunk {
+			if column != "" {
+				term = column + ":" + term
+			}
+			expr[i] = term
+		}
+		q.args = append(q.args, strings.Join(expr, " OR "))
+		clauses = append(clauses, "j.rowid IN (SELECT docid FROM job_index WHERE job_index MATCH ?)")
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")"
+}
+
+// in selects the jobs whose column has one of values.
+func in[T ~string](q *fileQuery, column string, values []T) string {
+	for _, value := range values {
+		q.args = append(q.args, string(value))
+	}
+	return column + " IN (" + placeholders(len(values)) + ")"
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// hasSkill selects the jobs with any of the skills names, as 1 or 0.
+func (q *fileQuery) hasSkill(names []string) string {
+	if len(names) == 0 {
+		return "0"
+	}
+	for _, name := range names {
+		q.args = append(q.args, name)
+	}
+	return "EXISTS (SELECT 1 FROM job_skills s WHERE s.job = j.rowid AND s.skill IN (" + placeholders(len(names)) + "))"
+}
+
+// resolveSkill returns the stored skill names that match skill. They are the
+// names with its canonical name or, if no job has it, misspellings of it,
+// which are listed as variants in the matcher.
+func (q *fileQuery) resolveSkill(skill string) []string {
+	want := strings.ToLower(q.skills.Canonical(skill))
+
+	var exact, fuzzy, variants []string
+	for _, name := range q.skillNames() {
+		have := strings.ToLower(q.skills.Canonical(name))
+		switch {
+		case have == want:
+			exact = append(exact, name)
+		case fuzzyMatch(have, want):
+			fuzzy = append(fuzzy, name)
+			variants = append(variants, have)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+
+	if len(variants) > 0 {
+		if q.matcher.skillVariants == nil {
+			q.matcher.skillVariants = map[string][]string{}
+		}
+		q.matcher.skillVariants[want] = variants
+	}
+	return fuzzy
+}
+
+// resolveLocation returns the location terms of the index that the words of
+// location match. They are the words themselves or, for a word no job
+// location has, misspellings of it, which are listed as variants in the
+// matcher.
+func (q *fileQuery) resolveLocation(location string) []string {
+	var terms []string
+	for _, word := range tokenize(location) {
+		var exact, fuzzy, variants []string
+		for _, term := range q.vocabulary() {
+			if term.columns&locationColumn == 0 {
+				continue
+			}
+			for _, w := range term.words {
+				switch {
+				case w == word:
+					exact = append(exact, term.raw)
+				case fuzzyMatch(w, word):
+					fuzzy = append(fuzzy, term.raw)
+					variants = append(variants, w)
+				}
+			}
+		}
+		if len(exact) > 0 {
+			terms = append(terms, exact...)
+			continue
+		}
+
+		terms = append(terms, fuzzy...)
+		if len(variants) > 0 {
+			if q.matcher.locationVariants == nil {
+				q.matcher.locationVariants = map[string][]string{}
+			}
+			q.matcher.locationVariants[word] = variants
+		}
+	}
+	return terms
+}
+
+// vocabulary returns the terms of the index.
+func (q *fileQuery) vocabulary() []indexTerm {
+	if q.terms != nil || q.err != nil {
+		return q.terms
+	}
+
+	rows, err := q.tx.QueryContext(q.ctx, `SELECT term, col FROM job_terms WHERE col != '*'`)
+	if err != nil {
+		q.err = fmt.Errorf("error reading index terms: %w", err)
+		return nil
+	}
+	defer rows.Close()
+
+	q.terms = []indexTerm{}
+	positions := map[string]int{}
+	for rows.Next() {
+		var raw, col string
+		if err := rows.Scan(&raw, &col); err != nil {
+			q.err = fmt.Errorf("error reading index terms: %w", err)
+			return nil
+		}
+		column, err := strconv.Atoi(col)
+		if err != nil {
+			continue
+		}
+
+		i, ok := positions[raw]
+		if !ok {
+			i = len(q.terms)
+			positions[raw] = i
+			q.terms = append(q.terms, indexTerm{raw: raw, words: tokenize(raw)})
+		}
+		q.terms[i].columns |= 1 << column
+	}
+	if err := rows.Err(); err != nil {
+		q.err = fmt.Errorf("error reading index terms: %w", err)
+	}
+	return q.terms
+}
+
+// skillNames returns the distinct skills of all jobs.
+func (q *fileQuery) skillNames() []string {
+	if q.storedSkills != nil || q.err != nil {
+		return q.storedSkills
+	}
+
+	rows, err := q.tx.QueryContext(q.ctx, `SELECT DISTINCT skill FROM job_skills`)
+	if err != nil {
+		q.err = fmt.Errorf("error reading skills: %w", err)
+		return nil
+	}
+	defer rows.Close()
+
+	q.storedSkills = []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			q.err = fmt.Errorf("error reading skills: %w", err)
+			return nil
+		}
+		q.storedSkills = append(q.storedSkills, name)
+	}
+	if err := rows.Err(); err != nil {
+		q.err = fmt.Errorf("error reading skills: %w", err)
+	}
+	return q.storedSkills
+}
+
+// Suggest completes titles and companies whose words start with the words of
+// prefix, and skills starting with prefix, like MemoryJobRepository.
+func (r *FileJobRepository) Suggest(ctx context.Context, prefix string, field models.SuggestField, size int) ([]models.Suggestion, error) {
+	words := tokenize(prefix)
+
+	var (
+		query string
+		args  []interface{}
+	)
+	switch field {
+	case models.SuggestTitle, models.SuggestCompany:
+		if len(words) == 0 {
+			return []models.Suggestion{}, nil
+		}
+		column := "title"
+		if field == models.SuggestCompany {
+			column = "company"
+		}
+		terms := make([]string, len(words))
+		for i, word := range words {
+			terms[i] = column + ":" + word + "*"
+		}
+		query = fmt.Sprintf(`SELECT %[1]s, count(*) FROM job_index WHERE job_index MATCH ? GROUP BY %[1]s`, column)
+		args = append(args, strings.Join(terms, " "))
+	case models.SuggestSkill:
+		query = `SELECT skill, count(*) FROM job_skills GROUP BY skill`
+	default:
+		return nil, fmt.Errorf("unsupported suggestion field %q", field)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error reading suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var (
+			text  string
+			count int
+		)
+		if err := rows.Scan(&text, &count); err != nil {
+			return nil, fmt.Errorf("error reading suggestions: %w", err)
+		}
+		if field == models.SuggestSkill {
+			if strings.HasPrefix(strings.ToLower(text), strings.ToLower(prefix)) {
+				counts[text] += count
+			}
+		} else if completes(tokenize(text), words) {
+			counts[text] += count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading suggestions: %w", err)
+	}
+
+	return rankSuggestions(counts, size), nil
+}
+
+func facetRequested(facets []models.FacetRequest, field models.FacetField) bool {
+	for _, facet := range facets {
+		if facet.Field == field {
+			return true
+		}
+	}
+	return false
+}
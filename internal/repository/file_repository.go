@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"job-search-service/internal/models"
+	"job-search-service/internal/taxonomy"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// fileSchema creates the tables of a FileJobRepository. Jobs are stored as
+// JSON next to the columns searches filter on. job_index is a full-text index
+// of their text, tokenized like tokenize does, and job_terms its vocabulary;
+// job_skills lists the skills of each job.
+const fileSchema = `
+CREATE TABLE IF NOT EXISTS jobs (
+	id                TEXT PRIMARY KEY,
+	seq_no            INTEGER NOT NULL,
+	doc               TEXT NOT NULL,
+	location_lower    TEXT NOT NULL,
+	employment_type   TEXT NOT NULL,
+	seniority         TEXT NOT NULL,
+	work_mode         TEXT NOT NULL,
+	annual_salary_min REAL NOT NULL,
+	annual_salary_max REAL NOT NULL,
+	created_at        INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS job_skills (
+	job   INTEGER NOT NULL,
+	skill TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS job_skills_job ON job_skills (job);
+CREATE INDEX IF NOT EXISTS job_skills_skill ON job_skills (skill);
+CREATE VIRTUAL TABLE IF NOT EXISTS job_index USING fts4(
+	title, description, company, location,
+	tokenize=unicode61 "remove_diacritics=0" "tokenchars=+#"
+);
+CREATE VIRTUAL TABLE IF NOT EXISTS job_terms USING fts4aux(job_index);
+CREATE TABLE IF NOT EXISTS sequence (
+	seq_no INTEGER NOT NULL
+);
+INSERT INTO sequence SELECT 0 WHERE NOT EXISTS (SELECT 1 FROM sequence);
+`
+
+// FileJobRepository is a JobStore keeping jobs in a local SQLite file with a
+// full-text index, for small single-node deployments without Elasticsearch.
+// A search looks up the jobs that can match in the index and evaluates the
+// request on those like MemoryJobRepository, so both support the same
+// requests. Unlike the other backends, the location and skills filters also
+// accept misspellings of values that no job has. It needs a cgo build.
+type FileJobRepository struct {
+	db     *sql.DB
+	skills *taxonomy.Taxonomy
+	// mu serialises writes so that a version check and the write it guards
+	// are not interleaved with other writes.
+	mu sync.Mutex
+}
+
+// OpenFileJobRepository opens or creates the store at path.
+func OpenFileJobRepository(path string, skills *taxonomy.Taxonomy) (*FileJobRepository, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("error creating data directory: %w", err)
+	}
+	db, err := sql.Open("sqlite3", path+"?_busy_timeout=5000&_journal_mode=WAL")
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %w", path, err)
+	}
+	if _, err := db.Exec(fileSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error creating tables in %s: %w", path, err)
+	}
+
+	return &FileJobRepository{db: db, skills: skills}, nil
+}
+
+// Close releases the file.
+func (r *FileJobRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *FileJobRepository) Create(ctx context.Context, job *models.Job) error {
+	failures, err := r.CreateBatch(ctx, []*models.Job{job})
+	if err != nil {
+		return err
+	}
+	return failures[0]
+}
+
+// CreateBatch stores jobs in one transaction. Jobs are searchable right
+// away. A job whose ID is taken fails with ErrVersionConflict like in
+// Elasticsearch.
+func (r *FileJobRepository) CreateBatch(ctx context.Context, jobs []*models.Job) ([]error, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	failures := make([]error, len(jobs))
+	seqNos := make([]int64, len(jobs))
+	err := r.update(ctx, func(tx *sql.Tx) error {
+		for i, job := range jobs {
+			_, _, err := lookupJob(ctx, tx, job.ID)
+			switch {
+			case err == nil:
+				failures[i] = fmt.Errorf("job %s already exists: %w", job.ID, ErrVersionConflict)
+				continue
+			case !errors.Is(err, ErrJobNotFound):
+				return err
+			}
+
+			if seqNos[i], err = putJob(ctx, tx, job, 0); err != nil {
+				return fmt.Errorf("error storing job %s: %w", job.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, job := range jobs {
+		if failures[i] == nil {
+			job.Version = localVersion(seqNos[i])
+		}
+	}
+	return failures, nil
+}
+
+func (r *FileJobRepository) Refresh(ctx context.Context) error {
+	return nil
+}
+
+func (r *FileJobRepository) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	var (
+		seqNo int64
+		doc   string
+	)
+	err := r.db.QueryRowContext(ctx, `SELECT seq_no, doc FROM jobs WHERE id = ?`, id).Scan(&seqNo, &doc)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, jobNotFound(id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading job %s: %w", id, err)
+	}
+
+	stored, err := decodeStoredJob(seqNo, doc)
+	if err != nil {
+		return nil, err
+	}
+	job := stored.job
+	job.Version = localVersion(seqNo)
+	return job, nil
+}
+
+func (r *FileJobRepository) Update(ctx context.Context, job *models.Job, expectedVersion string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var seqNo int64
+	err := r.update(ctx, func(tx *sql.Tx) error {
+		rowID, current, err := lookupJob(ctx, tx, job.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkLocalVersion(job.ID, current, expectedVersion); err != nil {
+			return err
+		}
+		if seqNo, err = putJob(ctx, tx, job, rowID); err != nil {
+			return fmt.Errorf("error storing job %s: %w", job.ID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	job.Version = localVersion(seqNo)
+	return nil
+}
+
+func (r *FileJobRepository) Delete(ctx context.Context, id string, expectedVersion string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.update(ctx, func(tx *sql.Tx) error {
+		rowID, current, err := lookupJob(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkLocalVersion(id, current, expectedVersion); err != nil {
+			return err
+		}
+
+		for _, query := range []string{
+			`DELETE FROM jobs WHERE rowid = ?`,
+			`DELETE FROM job_index WHERE docid = ?`,
+			`DELETE FROM job_skills WHERE job = ?`,
+		} {
+			if _, err := tx.ExecContext(ctx, query, rowID); err != nil {
+				return fmt.Errorf("error deleting job %s: %w", id, err)
+			}
+		}
+		return nil
+	})
+}
+
+// update runs write in a transaction and commits it if write succeeds.
+func (r *FileJobRepository) update(ctx context.Context, write func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := write(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+// lookupJob returns the row and the sequence number of the last write of a
+// stored job.
+func lookupJob(ctx context.Context, tx *sql.Tx, id string) (rowID, seqNo int64, err error) {
+	err = tx.QueryRowContext(ctx, `SELECT rowid, seq_no FROM jobs WHERE id = ?`, id).Scan(&rowID, &seqNo)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, 0, jobNotFound(id)
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("error reading job %s: %w", id, err)
+	}
+	return rowID, seqNo, nil
+}
+
+// putJob writes job under the next sequence number, over the job stored in
+// row rowID or, if that is 0, as a new row, and returns the sequence number.
+func putJob(ctx context.Context, tx *sql.Tx, job *models.Job, rowID int64) (int64, error) {
+	var seqNo int64
+	if err := tx.QueryRowContext(ctx, `UPDATE sequence SET seq_no = seq_no + 1 RETURNING seq_no`).Scan(&seqNo); err != nil {
+		return 0, err
+	}
+
+	doc, err := json.Marshal(cloneJob(job))
+	if err != nil {
+		return 0, err
+	}
+	columns := []interface{}{
+		job.ID, seqNo, string(doc), strings.ToLower(job.Location),
+		string(job.EmploymentType), string(job.Seniority), string(job.WorkMode),
+		job.AnnualSalaryMin, job.AnnualSalaryMax, job.CreatedAt.UnixNano(),
+	}
+	text := []interface{}{job.Title, job.Description, job.Company, job.Location}
+
+	if rowID == 0 {
+		res, err := tx.ExecContext(ctx, `INSERT INTO jobs (id, seq_no, doc, location_lower, employment_type, seniority,
+			work_mode, annual_salary_min, annual_salary_max, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, columns...)
+		if err != nil {
+			return 0, err
+		}
+		if rowID, err = res.LastInsertId(); err != nil {
+			return 0, err
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO job_index (title, description, company, location, docid)
+			VALUES (?, ?, ?, ?, ?)`, append(text, rowID)...)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		_, err := tx.ExecContext(ctx, `UPDATE jobs SET id = ?, seq_no = ?, doc = ?, location_lower = ?, employment_type = ?,
+			seniority = ?, work_mode = ?, annual_salary_min = ?, annual_salary_max = ?, created_at = ?
+			WHERE rowid = ?`, append(columns, rowID)...)
+		if err != nil {
+			return 0, err
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE job_index SET title = ?, description = ?, company = ?, location = ?
+			WHERE docid = ?`, append(text, rowID)...)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM job_skills WHERE job = ?`, rowID); err != nil {
+			return 0, err
+		}
+	}
+
+	for _, skill := range job.Skills {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO job_skills (job, skill) VALUES (?, ?)`, rowID, skill); err != nil {
+			return 0, err
+		}
+	}
+	return seqNo, nil
+}
+
+func decodeStoredJob(seqNo int64, doc string) (storedJob, error) {
+	var job models.Job
+	if err := json.Unmarshal([]byte(doc), &job); err != nil {
+		return storedJob{}, fmt.Errorf("error decoding job: %w", err)
+	}
+	return storedJob{seqNo: seqNo, job: &job}, nil
+}
@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"job-search-service/internal/models"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func openTestFileStore(t *testing.T, path string) *FileJobRepository {
+	t.Helper()
+
+	repo, err := OpenFileJobRepository(path, testTaxonomy(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func newTestFileStore(t *testing.T) *FileJobRepository {
+	t.Helper()
+
+	repo := openTestFileStore(t, filepath.Join(t.TempDir(), "jobs.sqlite"))
+	for _, job := range testJobs {
+		job := job
+		if err := repo.Create(context.Background(), &job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return repo
+}
+
+// The index only preselects jobs, so the file store must return exactly what
+// the memory store does for searches without misspelled filters.
+func TestFileSearchMatchesMemory(t *testing.T) {
+	memory := newTestStore(t)
+	file := newTestFileStore(t)
+
+	tests := []struct {
+		name   string
+		params models.SearchParams
+	}{
+		{name: "everything"},
+		{name: "free text", params: models.SearchParams{Query: "engineer"}},
+		{name: "misspelled free text", params: models.SearchParams{Query: "enginer"}},
+		{name: "any of several words", params: models.SearchParams{Query: "scientist globex"}},
+		{name: "substring of a title", params: models.SearchParams{Query: "end"}},
+		{name: "phrase", params: models.SearchParams{Query: `"backend engineers"`}},
+		{name: "phrase in the wrong order", params: models.SearchParams{Query: `"engineer backend"`}},
+		{name: "field", params: models.SearchParams{Query: "title:engineers company:acme"}},
+		{name: "location field", params: models.SearchParams{Query: "location:remote"}},
+		{name: "negated text", params: models.SearchParams{Query: "engineer -platform"}},
+		{name: "alternatives", params: models.SearchParams{Query: "mode:remote OR type:part_time"}},
+		{name: "query language fields", params: models.SearchParams{Query: "skills:go salary:>76000"}},
+		{name: "skill alias", params: models.SearchParams{Skills: []string{"golang"}}},
+		{
+			name: "at least two skills",
+			params: models.SearchParams{
+				Skills:             []string{"Go", "k8s", "PostgreSQL", "Python"},
+				SkillsMatch:        models.SkillsMatchAtLeast,
+				SkillsMinimumMatch: 2,
+			},
+		},
+		{name: "location", params: models.SearchParams{Location: "berlin"}},
+		{name: "location substring", params: models.SearchParams{Location: "unic"}},
+		{name: "salary range overlap", params: models.SearchParams{MinSalary: amountOf(74000), MaxSalary: amountOf(76000)}},
+		{name: "posted between", params: models.SearchParams{PostedAfter: day(time.February, 1), PostedBefore: day(time.May, 1)}},
+		{name: "remote country", params: models.SearchParams{RemoteCountry: "US"}},
+		{
+			name: "filters and facets",
+			params: models.SearchParams{
+				Query:           "engineer OR developer",
+				Location:        "Berlin",
+				Skills:          []string{"Go"},
+				EmploymentTypes: []models.EmploymentType{models.EmploymentFullTime},
+				WorkModes:       []models.WorkMode{models.WorkModeOnsite, models.WorkModeHybrid},
+				Facets: []models.FacetRequest{
+					{Field: models.FacetLocation, Size: 10},
+					{Field: models.FacetSkills, Size: 10},
+					{Field: models.FacetEmploymentType, Size: 10},
+				},
+			},
+		},
+		{
+			name:   "page",
+			params: models.SearchParams{Query: "engineer OR developer", Sort: []models.SortOption{{Field: models.SortByCreatedAt}}, PageSize: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			if params.PageSize == 0 {
+				params.PageSize = 10
+			}
+
+			want, err := memory.Search(context.Background(), params)
+			if err != nil {
+				t.Fatalf("memory Search returned error: %v", err)
+			}
+			got, err := file.Search(context.Background(), params)
+			if err != nil {
+				t.Fatalf("Search returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Search returned %v (total %d, facets %v), memory store %v (total %d, facets %v)",
+					jobIDs(got.Jobs), got.Total, got.Facets, jobIDs(want.Jobs), want.Total, want.Facets)
+			}
+		})
+	}
+}
+
+func TestFileSearchCorrectsFilters(t *testing.T) {
+	repo := newTestFileStore(t)
+	ctx := context.Background()
+	for _, job := range []models.Job{
+		{ID: "f", Title: "Database Engineer", Location: "Vienna", Skills: []string{"SQL"}},
+		{ID: "g", Title: "Cloud Engineer", Location: "Vienna", Skills: []string{"SQS"}},
+	} {
+		job := job
+		if err := repo.Create(ctx, &job); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		params models.SearchParams
+		want   []string
+	}{
+		{name: "misspelled location", params: models.SearchParams{Location: "Berlim"}, want: []string{"a"}},
+		{name: "misspelled skill", params: models.SearchParams{Skills: []string{"Pyton"}}, want: []string{"d"}},
+		{name: "misspelled skill term", params: models.SearchParams{Query: "skills:kubernets"}, want: []string{"a", "c"}},
+		{
+			// A skill some job has is never taken for a misspelling.
+			name:   "skill close to another",
+			params: models.SearchParams{Skills: []string{"SQL"}},
+			want:   []string{"f"},
+		},
+		{name: "unknown location", params: models.SearchParams{Location: "Lisbon"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			params.PageSize = 10
+
+			result, err := repo.Search(ctx, params)
+			if err != nil {
+				t.Fatalf("Search returned error: %v", err)
+			}
+			got := jobIDs(result.Jobs)
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search returned %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileSuggest(t *testing.T) {
+	memory := newTestStore(t)
+	file := newTestFileStore(t)
+
+	tests := []struct {
+		prefix string
+		field  models.SuggestField
+	}{
+		{"eng", models.SuggestTitle},
+		{"backend eng", models.SuggestTitle},
+		{"developers", models.SuggestTitle},
+		{"in", models.SuggestCompany},
+		{"k", models.SuggestSkill},
+		{"", models.SuggestTitle},
+	}
+
+	for _, tt := range tests {
+		want, err := memory.Suggest(context.Background(), tt.prefix, tt.field, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := file.Suggest(context.Background(), tt.prefix, tt.field, 10)
+		if err != nil {
+			t.Fatalf("Suggest(%q, %s) returned error: %v", tt.prefix, tt.field, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Suggest(%q, %s) = %v, memory store %v", tt.prefix, tt.field, got, want)
+		}
+	}
+}
+
+func TestFileReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.sqlite")
+	ctx := context.Background()
+
+	repo := openTestFileStore(t, path)
+	job := testJobs[0]
+	if err := repo.Create(ctx, &job); err != nil {
+		t.Fatal(err)
+	}
+	created := job.Version
+	if err := repo.Create(ctx, &job); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("Create of a stored ID error = %v, want ErrVersionConflict", err)
+	}
+	job.Title = "Staff Backend Engineer"
+	if err := repo.Update(ctx, &job, created); err != nil {
+		t.Fatal(err)
+	}
+	updated := job.Version
+	other := testJobs[1]
+	if err := repo.Create(ctx, &other); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	repo = openTestFileStore(t, path)
+	stored, err := repo.GetByID(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Title != "Staff Backend Engineer" || stored.Version != updated {
+		t.Errorf("reopened job has title %q and version %s, want the update with version %s", stored.Title, stored.Version, updated)
+	}
+	if err := repo.Update(ctx, stored, created); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("Update with a version from before the reopen error = %v, want ErrVersionConflict", err)
+	}
+
+	// The index was updated along with the job.
+	result, err := repo.Search(ctx, models.SearchParams{Query: "staff", PageSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := jobIDs(result.Jobs); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("Search returned %v, want [a]", got)
+	}
+
+	if err := repo.Delete(ctx, "a", stored.Version); err != nil {
+		t.Fatalf("Delete with the current version: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "a"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("GetByID after Delete error = %v, want ErrJobNotFound", err)
+	}
+	result, err = repo.Search(ctx, models.SearchParams{Query: "engineer OR developer", PageSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := jobIDs(result.Jobs); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("Search after Delete returned %v, want [b]", got)
+	}
+}
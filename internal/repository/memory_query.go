@@ -0,0 +1,670 @@
+package repository
+
+import (
+	"job-search-service/internal/geo"
+	"job-search-service/internal/models"
+	"job-search-service/internal/querylang"
+	"job-search-service/internal/ranking"
+	"job-search-service/internal/taxonomy"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// memoryMatcher evaluates searches against jobs in memory, mirroring
+// buildQuery.
+type memoryMatcher struct {
+	params  models.SearchParams
+	profile *ranking.Profile
+	skills  *taxonomy.Taxonomy
+	// skillVariants and locationVariants widen the skills and location
+	// filters: a lowercased canonical skill name or location word also
+	// matches the values listed for it. FileJobRepository lists the
+	// corrections of misspelled values here; the memory store matches
+	// exactly.
+	skillVariants    map[string][]string
+	locationVariants map[string][]string
+}
+
+func newMemoryMatcher(params models.SearchParams, skills *taxonomy.Taxonomy) *memoryMatcher {
+	profile := params.Ranking
+	if profile == nil {
+		profile = &ranking.Legacy
+	}
+	return &memoryMatcher{params: params, profile: profile, skills: skills}
+}
+
+// memoryFilter is the in-memory form of a searchFilter.
+type memoryFilter struct {
+	facet models.FacetField
+	match func(job *models.Job) bool
+}
+
+func passes(filters []memoryFilter, job *models.Job, exclude models.FacetField) bool {
+	for _, filter := range filters {
+		if exclude != "" && filter.facet == exclude {
+			continue
+		}
+		if !filter.match(job) {
+			return false
+		}
+	}
+	return true
+}
+
+// filters mirrors searchFilters.
+func (m *memoryMatcher) filters() []memoryFilter {
+	params := m.params
+	filters := []memoryFilter{}
+
+	if params.Location != "" {
+		filters = append(filters, memoryFilter{facet: models.FacetLocation, match: func(job *models.Job) bool {
+			return matchLocation(job.Location, params.Location, m.locationVariants)
+		}})
+	}
+
+	if len(params.Skills) > 0 {
+		required := skillsMinimumMatch(params)
+		filters = append(filters, memoryFilter{facet: models.FacetSkills, match: func(job *models.Job) bool {
+			return len(m.matchedSkills(job)) >= required
+		}})
+	}
+
+	if params.MinSalary != nil || params.MaxSalary != nil {
+		filters = append(filters, memoryFilter{facet: models.FacetSalary, match: func(job *models.Job) bool {
+			return salaryOverlaps(job, params.MinSalary, params.MaxSalary, true, true)
+		}})
+	}
+
+	if len(params.EmploymentTypes) > 0 {
+		filters = append(filters, memoryFilter{facet: models.FacetEmploymentType, match: func(job *models.Job) bool {
+			return contains(params.EmploymentTypes, job.EmploymentType)
+		}})
+	}
+
+	if len(params.Seniorities) > 0 {
+		filters = append(filters, memoryFilter{facet: models.FacetSeniority, match: func(job *models.Job) bool {
+			return contains(params.Seniorities, job.Seniority)
+		}})
+	}
+
+	if !params.PostedAfter.IsZero() || !params.PostedBefore.IsZero() {
+		filters = append(filters, memoryFilter{facet: models.FacetCreatedAt, match: func(job *models.Job) bool {
+			return (params.PostedAfter.IsZero() || !job.CreatedAt.Before(params.PostedAfter)) &&
+				(params.PostedBefore.IsZero() || job.CreatedAt.Before(params.PostedBefore))
+		}})
+	}
+
+	if near := params.Near; near != nil && near.Origin != nil && near.RadiusKm > 0 {
+		filters = append(filters, memoryFilter{match: func(job *models.Job) bool {
+			return job.Geo != nil && geo.DistanceKm(*near.Origin, *job.Geo) <= near.RadiusKm
+		}})
+	}
+
+	if len(params.WorkModes) > 0 {
+		filters = append(filters, memoryFilter{match: func(job *models.Job) bool {
+			return contains(params.WorkModes, job.WorkMode)
+		}})
+	}
+
+	if country := params.RemoteCountry; country != "" {
+		filters = append(filters, memoryFilter{match: func(job *models.Job) bool {
+			if job.WorkMode != models.WorkModeRemote || job.Remote == nil || len(job.Remote.AllowedCountries) == 0 {
+				return true
+			}
+			return contains(job.Remote.AllowedCountries, country)
+		}})
+	}
+
+	if offset := params.RemoteUTCOffsetMinutes; offset != nil {
+		filters = append(filters, memoryFilter{match: func(job *models.Job) bool {
+			if job.WorkMode != models.WorkModeRemote || job.Remote == nil {
+				return true
+			}
+			policy := job.Remote
+			return (policy.MinUTCOffsetMinutes == nil || *policy.MinUTCOffsetMinutes <= *offset) &&
+				(policy.MaxUTCOffsetMinutes == nil || *policy.MaxUTCOffsetMinutes >= *offset)
+		}})
+	}
+
+	return filters
+}
+
+// matchedSkills returns the requested skills job has, in request order.
+func (m *memoryMatcher) matchedSkills(job *models.Job) []string {
+	var matched []string
+	for _, skill := range m.params.Skills {
+		if m.hasSkill(job, skill) {
+			matched = append(matched, skill)
+		}
+	}
+	return matched
+}
+
+func (m *memoryMatcher) hasSkill(job *models.Job, skill string) bool {
+	want := strings.ToLower(m.skills.Canonical(skill))
+	for _, have := range job.Skills {
+		have = strings.ToLower(m.skills.Canonical(have))
+		if have == want || contains(m.skillVariants[want], have) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchQuery reports whether job matches the parsed query and its text score.
+// A nil query matches everything with a score of 1 like match_all.
+func (m *memoryMatcher) matchQuery(node querylang.Node, job *models.Job) (bool, float64) {
+	if node == nil {
+		return true, 1
+	}
+	return m.match(node, job, false)
+}
+
+func (m *memoryMatcher) match(node querylang.Node, job *models.Job, negated bool) (bool, float64) {
+	switch n := node.(type) {
+	case *querylang.And:
+		return m.matchAnd(n, job, negated)
+	case *querylang.Or:
+		matched, score := false, 0.0
+		for _, clause := range n.Clauses {
+			if ok, s := m.match(clause, job, negated); ok {
+				matched, score = true, score+s
+			}
+		}
+		return matched, score
+	case *querylang.Not:
+		ok, _ := m.match(n.Clause, job, !negated)
+		return !ok, 0
+	case *querylang.Term:
+		return m.matchTerm(n, job, negated)
+	case *querylang.Range:
+		return matchRange(n, job), 0
+	default:
+		return true, 1
+	}
+}
+
+// matchAnd requires every clause, except that free words listed next to each
+// other are one text query of which any word may match, as in
+// Translator.translateAnd.
+func (m *memoryMatcher) matchAnd(n *querylang.And, job *models.Job, negated bool) (bool, float64) {
+	score := 0.0
+	words := []string{}
+
+	for _, clause := range n.Clauses {
+		if term, ok := clause.(*querylang.Term); ok && term.Field == "" && !term.Phrase && !negated {
+			words = append(words, term.Value)
+			continue
+		}
+		ok, s := m.match(clause, job, negated)
+		if !ok {
+			return false, 0
+		}
+		score += s
+	}
+
+	if len(words) > 0 {
+		ok, s := m.matchText(strings.Join(words, " "), job, false)
+		if !ok {
+			return false, 0
+		}
+		score += s
+	}
+	return true, score
+}
+
+func (m *memoryMatcher) matchTerm(n *querylang.Term, job *models.Job, negated bool) (bool, float64) {
+	switch n.Field {
+	case "":
+		return m.matchText(n.Value, job, n.Phrase || negated)
+	case "skills", "skill":
+		return m.hasSkill(job, n.Value), 0
+	case "mode":
+		return string(job.WorkMode) == n.Value, 0
+	case "type":
+		return string(job.EmploymentType) == n.Value, 0
+	case "seniority":
+		return string(job.Seniority) == n.Value, 0
+	}
+
+	text := map[string]string{
+		"title":       job.Title,
+		"description": job.Description,
+		"company":     job.Company,
+		"location":    job.Location,
+	}[n.Field]
+	tokens := tokenize(text)
+	if n.Phrase {
+		return containsPhrase(tokens, tokenize(n.Value)), 1
+	}
+	for _, word := range tokenize(n.Value) {
+		if !containsToken(tokens, word) {
+			return false, 0
+		}
+	}
+	return true, 1
+}
+
+// matchText searches title, description and company with the field boosts of
+// the ranking profile. Exact text must occur as a phrase; otherwise any word
+// matches, also misspelled like fuzziness AUTO or as a substring of a title or
+// company word of at least three letters like the trigram subfields.
+func (m *memoryMatcher) matchText(text string, job *models.Job, exact bool) (bool, float64) {
+	words := tokenize(text)
+	fields := []struct {
+		name       string
+		value      string
+		substrings bool
+	}{
+		{"title", job.Title, true},
+		{"description", job.Description, false},
+		{"company", job.Company, true},
+	}
+
+	matched, score := false, 0.0
+	for _, field := range fields {
+		boost, ok := m.profile.FieldBoosts[field.name]
+		if !ok {
+			boost = 1
+		}
+		if boost == 0 {
+			continue
+		}
+
+		tokens := tokenize(field.value)
+		if exact {
+			if containsPhrase(tokens, words) {
+				matched, score = true, score+boost
+			}
+			continue
+		}
+		for _, word := range words {
+			switch {
+			case containsToken(tokens, word),
+				field.substrings && len(word) >= 3 && strings.Contains(strings.ToLower(field.value), word):
+				matched, score = true, score+boost
+			case containsFuzzy(tokens, word):
+				// Misspelled words score lower, as fuzzy terms do.
+				matched, score = true, score+boost/2
+			}
+		}
+	}
+	return matched, score
+}
+
+// matchLocation matches any word of the location, or one of its variants,
+// or the location as a substring, like the location filter.
+func matchLocation(location, query string, variants map[string][]string) bool {
+	tokens := tokenize(location)
+	for _, word := range tokenize(query) {
+		if containsToken(tokens, word) {
+			return true
+		}
+		for _, variant := range variants[word] {
+			if containsToken(tokens, variant) {
+				return true
+			}
+		}
+	}
+	return strings.Contains(strings.ToLower(location), strings.ToLower(strings.TrimSpace(query)))
+}
+
+// matchRange mirrors translateRange: salaries overlap the annual range and
+// dates compare by whole days.
+func matchRange(n *querylang.Range, job *models.Job) bool {
+	if n.Field == "salary" {
+		var from, to *float64
+		if n.From != "" {
+			value, _ := strconv.ParseFloat(n.From, 64)
+			from = &value
+		}
+		if n.To != "" {
+			value, _ := strconv.ParseFloat(n.To, 64)
+			to = &value
+		}
+		return salaryOverlaps(job, from, to, n.IncludeFrom, n.IncludeTo)
+	}
+
+	created := job.CreatedAt.UTC()
+	if n.From != "" {
+		day, _ := time.Parse("2006-01-02", n.From)
+		if !n.IncludeFrom {
+			day = day.AddDate(0, 0, 1)
+		}
+		if created.Before(day) {
+			return false
+		}
+	}
+	if n.To != "" {
+		day, _ := time.Parse("2006-01-02", n.To)
+		if n.IncludeTo {
+			day = day.AddDate(0, 0, 1)
+		}
+		if !created.Before(day) {
+			return false
+		}
+	}
+	return true
+}
+
+// salaryOverlaps reports whether the annual salary range of job overlaps
+// [from, to]. Jobs without a salary never match.
+func salaryOverlaps(job *models.Job, from, to *float64, includeFrom, includeTo bool) bool {
+	if job.AnnualSalaryMin == 0 && job.AnnualSalaryMax == 0 {
+		return false
+	}
+	if from != nil && (job.AnnualSalaryMax < *from || (!includeFrom && job.AnnualSalaryMax == *from)) {
+		return false
+	}
+	if to != nil && (job.AnnualSalaryMin > *to || (!includeTo && job.AnnualSalaryMin == *to)) {
+		return false
+	}
+	return true
+}
+
+// tokenize lowercases text and splits it into words, dropping a plural "s"
+// as a rough stand-in for the light English stemmer.
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+	for i, word := range words {
+		if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+			words[i] = strings.TrimSuffix(word, "s")
+		}
+	}
+	return words
+}
+
+func containsToken(tokens []string, word string) bool {
+	for _, token := range tokens {
+		if token == word {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFuzzy(tokens []string, word string) bool {
+	for _, token := range tokens {
+		if fuzzyMatch(token, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// fuzzyMatch reports whether word is within the edits fuzziness AUTO allows
+// of token: none up to two characters, one up to five and two beyond.
+func fuzzyMatch(token, word string) bool {
+	if token == word {
+		return true
+	}
+
+	limit := 2
+	switch n := utf8.RuneCountInString(word); {
+	case n <= 2:
+		return false
+	case n <= 5:
+		limit = 1
+	}
+	return editDistance([]rune(token), []rune(word)) <= limit
+}
+
+// editDistance counts the insertions, deletions, substitutions and
+// transpositions of adjacent characters that turn a into b.
+func editDistance(a, b []rune) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(a)][len(b)]
+}
+
+func containsPhrase(tokens, phrase []string) bool {
+	if len(phrase) == 0 {
+		return false
+	}
+	for i := 0; i+len(phrase) <= len(tokens); i++ {
+		match := true
+		for j, word := range phrase {
+			if tokens[i+j] != word {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func contains[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sortHits orders hits like buildSort: by the requested options with missing
+// values last, then by score and finally by ID.
+func sortHits(hits []memoryHit, options []models.SortOption, origin *models.GeoPoint) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+		scoreSorted := false
+		for _, option := range options {
+			if option.Field == models.SortByRelevance {
+				scoreSorted = true
+			}
+			if c := compareBy(option, a, b, origin); c != 0 {
+				return c < 0
+			}
+		}
+		if !scoreSorted && a.score != b.score {
+			return a.score > b.score
+		}
+		return a.stored.job.ID < b.stored.job.ID
+	})
+}
+
+// compareBy returns a negative number when a sorts before b by option.
+func compareBy(option models.SortOption, a, b memoryHit, origin *models.GeoPoint) int {
+	var av, bv interface{}
+	switch option.Field {
+	case models.SortByRelevance:
+		c := compareFloat(a.score, b.score)
+		if !option.Descending {
+			return c
+		}
+		return -c
+	case models.SortByCreatedAt:
+		av, bv = float64(a.stored.job.CreatedAt.UnixNano()), float64(b.stored.job.CreatedAt.UnixNano())
+	case models.SortBySalary:
+		av, bv = optionalFloat(a.stored.job.AnnualSalaryMax), optionalFloat(b.stored.job.AnnualSalaryMax)
+	case models.SortByTitle:
+		av, bv = optionalString(a.stored.job.Title), optionalString(b.stored.job.Title)
+	case models.SortByCompany:
+		av, bv = optionalString(a.stored.job.Company), optionalString(b.stored.job.Company)
+	case models.SortByDistance:
+		if origin == nil {
+			return 0
+		}
+		av, bv = distance(a.stored.job, origin), distance(b.stored.job, origin)
+	default:
+		return 0
+	}
+
+	// Missing values come last in either order.
+	switch {
+	case av == nil && bv == nil:
+		return 0
+	case av == nil:
+		return 1
+	case bv == nil:
+		return -1
+	}
+
+	var c int
+	switch x := av.(type) {
+	case float64:
+		c = compareFloat(x, bv.(float64))
+	case string:
+		c = strings.Compare(x, bv.(string))
+	}
+	if option.Descending {
+		return -c
+	}
+	return c
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func optionalFloat(value float64) interface{} {
+	if value == 0 {
+		return nil
+	}
+	return value
+}
+
+func optionalString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func distance(job *models.Job, origin *models.GeoPoint) interface{} {
+	if job.Geo == nil {
+		return nil
+	}
+	return geo.DistanceKm(*origin, *job.Geo)
+}
+
+// memoryFacets counts facet values like buildAggregations: each facet over
+// the jobs matching the query and all filters except its own.
+func memoryFacets(facets []models.FacetRequest, candidates []memoryHit, filters []memoryFilter) map[models.FacetField][]models.FacetBucket {
+	if len(facets) == 0 {
+		return nil
+	}
+
+	result := make(map[models.FacetField][]models.FacetBucket, len(facets))
+	for _, facet := range facets {
+		counts := map[string]int64{}
+		for _, hit := range candidates {
+			if !passes(filters, hit.stored.job, facet.Field) {
+				continue
+			}
+			for _, key := range facetKeys(facet, hit.stored.job) {
+				counts[key]++
+			}
+		}
+
+		buckets := make([]models.FacetBucket, 0, len(counts))
+		for key, count := range counts {
+			buckets = append(buckets, models.FacetBucket{Key: key, Count: count})
+		}
+		switch facet.Field {
+		case models.FacetSalary:
+			sort.Slice(buckets, func(i, j int) bool {
+				a, _ := strconv.ParseFloat(buckets[i].Key, 64)
+				b, _ := strconv.ParseFloat(buckets[j].Key, 64)
+				return a < b
+			})
+		case models.FacetCreatedAt:
+			sort.Slice(buckets, func(i, j int) bool { return buckets[i].Key < buckets[j].Key })
+		default:
+			sort.Slice(buckets, func(i, j int) bool {
+				if buckets[i].Count != buckets[j].Count {
+					return buckets[i].Count > buckets[j].Count
+				}
+				return buckets[i].Key < buckets[j].Key
+			})
+			if len(buckets) > facet.Size {
+				buckets = buckets[:facet.Size]
+			}
+		}
+		result[facet.Field] = buckets
+	}
+	return result
+}
+
+// facetKeys returns the bucket keys job counts towards.
+func facetKeys(facet models.FacetRequest, job *models.Job) []string {
+	var value string
+	switch facet.Field {
+	case models.FacetSkills:
+		return job.Skills
+	case models.FacetLocation:
+		value = job.Location
+	case models.FacetCompany:
+		value = job.Company
+	case models.FacetEmploymentType:
+		value = string(job.EmploymentType)
+	case models.FacetSeniority:
+		value = string(job.Seniority)
+	case models.FacetSalary:
+		if job.AnnualSalaryMin == 0 && job.AnnualSalaryMax == 0 {
+			return nil
+		}
+		key := math.Floor(job.AnnualSalaryMin/facet.Interval) * facet.Interval
+		return []string{strconv.FormatFloat(key, 'f', -1, 64)}
+	case models.FacetCreatedAt:
+		return []string{calendarBucket(job.CreatedAt.UTC(), facet.CalendarInterval).Format("2006-01-02")}
+	}
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+// calendarBucket returns the start of the calendar interval t falls in.
+// Weeks start on Monday as in Elasticsearch.
+func calendarBucket(t time.Time, interval string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case "day":
+		return day
+	case "week":
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case "quarter":
+		return time.Date(t.Year(), (t.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+	case "year":
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
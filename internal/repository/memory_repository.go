@@ -0,0 +1,357 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"job-search-service/internal/geo"
+	"job-search-service/internal/models"
+	"job-search-service/internal/querylang"
+	"job-search-service/internal/taxonomy"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// memoryPitID marks page tokens issued by MemoryJobRepository.
+const memoryPitID = "memory"
+
+// MemoryJobRepository is a JobStore keeping jobs in process memory. It
+// follows the search semantics of JobRepository for the query language,
+// including fuzzy matching of free text, but has no highlighting, spelling
+// suggestions or function_score factors of ranking profiles. Pages are not
+// taken from a snapshot, so writes between page requests can shift results.
+type MemoryJobRepository struct {
+	mu     sync.RWMutex
+	jobs   map[string]*models.Job
+	seqNos map[string]int64
+	seqNo  int64
+	skills *taxonomy.Taxonomy
+}
+
+// storedJob is a job with the sequence number of its last write.
+type storedJob struct {
+	seqNo int64
+	job   *models.Job
+}
+
+// NewMemoryJobRepository creates an empty store. Skills are compared by their
+// canonical names in skills, like the synonyms set does in Elasticsearch.
+func NewMemoryJobRepository(skills *taxonomy.Taxonomy) *MemoryJobRepository {
+	return &MemoryJobRepository{
+		jobs:   make(map[string]*models.Job),
+		seqNos: make(map[string]int64),
+		skills: skills,
+	}
+}
+
+func (r *MemoryJobRepository) Create(ctx context.Context, job *models.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.write(job)
+	return nil
+}
+
+// CreateBatch stores jobs at once. Jobs are searchable right away.
+func (r *MemoryJobRepository) CreateBatch(ctx context.Context, jobs []*models.Job) ([]error, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.write(jobs...)
+	return make([]error, len(jobs)), nil
+}
+
+func (r *MemoryJobRepository) Refresh(ctx context.Context) error {
+	return nil
+}
+
+func (r *MemoryJobRepository) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, jobNotFound(id)
+	}
+	return r.read(job), nil
+}
+
+func (r *MemoryJobRepository) Update(ctx context.Context, job *models.Job, expectedVersion string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkVersion(job.ID, expectedVersion); err != nil {
+		return err
+	}
+	r.write(job)
+	return nil
+}
+
+func (r *MemoryJobRepository) Delete(ctx context.Context, id string, expectedVersion string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkVersion(id, expectedVersion); err != nil {
+		return err
+	}
+	delete(r.jobs, id)
+	delete(r.seqNos, id)
+	return nil
+}
+
+// checkVersion fails like Elasticsearch: a missing job is not found and a
+// job written since expectedVersion is a conflict.
+func (r *MemoryJobRepository) checkVersion(id, expectedVersion string) error {
+	if _, ok := r.jobs[id]; !ok {
+		return jobNotFound(id)
+	}
+	return checkLocalVersion(id, r.seqNos[id], expectedVersion)
+}
+
+// checkLocalVersion compares expectedVersion, if set, with the sequence
+// number of the last write to a job in an in-process store. Their versions
+// always have generation 0 and primary term 1 since there are no migrations
+// and no failover.
+func checkLocalVersion(id string, seqNo int64, expectedVersion string) error {
+	if expectedVersion == "" {
+		return nil
+	}
+
+	generation, expectedSeqNo, primaryTerm, err := parseVersion(expectedVersion)
+	if err != nil {
+		return err
+	}
+	if int64(expectedSeqNo) != seqNo || primaryTerm != 1 || generation != 0 {
+		return fmt.Errorf("job %s: %w", id, ErrVersionConflict)
+	}
+	return nil
+}
+
+func localVersion(seqNo int64) string {
+	return formatVersion(0, seqNo, 1)
+}
+
+// write stores copies of jobs under the next sequence numbers.
+func (r *MemoryJobRepository) write(jobs ...*models.Job) {
+	for _, job := range jobs {
+		r.seqNo++
+		job.Version = localVersion(r.seqNo)
+		r.jobs[job.ID] = cloneJob(job)
+		r.seqNos[job.ID] = r.seqNo
+	}
+}
+
+func (r *MemoryJobRepository) read(job *models.Job) *models.Job {
+	copied := cloneJob(job)
+	copied.Version = localVersion(r.seqNos[job.ID])
+	return copied
+}
+
+// cloneJob copies the stored fields of a job so that callers cannot change
+// stored jobs through shared slices or pointers.
+func cloneJob(job *models.Job) *models.Job {
+	copied := *job
+	copied.Skills = append([]string(nil), job.Skills...)
+	if job.Geo != nil {
+		point := *job.Geo
+		copied.Geo = &point
+	}
+	if job.Remote != nil {
+		remote := *job.Remote
+		remote.AllowedCountries = append([]string(nil), job.Remote.AllowedCountries...)
+		copied.Remote = &remote
+	}
+	copied.Score = 0
+	copied.Highlights = nil
+	copied.DistanceKm = 0
+	copied.MatchedSkills = nil
+	copied.Explanation = nil
+	return &copied
+}
+
+type memoryHit struct {
+	stored        storedJob
+	score         float64
+	matchedSkills []string
+}
+
+func (r *MemoryJobRepository) Search(ctx context.Context, params models.SearchParams) (*models.SearchResult, error) {
+	parsed, err := querylang.Parse(params.Query, querylang.Limits{})
+	if err != nil {
+		return nil, err
+	}
+
+	offset := 0
+	if params.PageToken != "" {
+		if offset, err = decodeMemoryPageToken(params.PageToken, params); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	jobs := make([]storedJob, 0, len(r.jobs))
+	for id, job := range r.jobs {
+		jobs = append(jobs, storedJob{seqNo: r.seqNos[id], job: job})
+	}
+	return searchStored(params, parsed, newMemoryMatcher(params, r.skills), offset, jobs)
+}
+
+// searchStored returns the page at offset of the jobs that match params,
+// evaluated in memory. jobs must include every stored job that can match;
+// others are filtered out.
+func searchStored(params models.SearchParams, parsed querylang.Node, matcher *memoryMatcher, offset int, jobs []storedJob) (*models.SearchResult, error) {
+	filters := matcher.filters()
+
+	var origin *models.GeoPoint
+	if params.Near != nil {
+		origin = params.Near.Origin
+	}
+
+	// candidates match the query; hits also pass every filter.
+	candidates := []memoryHit{}
+	hits := []memoryHit{}
+	for _, stored := range jobs {
+		ok, score := matcher.matchQuery(parsed, stored.job)
+		if !ok {
+			continue
+		}
+		hit := memoryHit{stored: stored, score: score}
+		hit.matchedSkills = matcher.matchedSkills(stored.job)
+		hit.score += float64(len(hit.matchedSkills)) * skillMatchBoost
+		candidates = append(candidates, hit)
+
+		if passes(filters, stored.job, "") {
+			hits = append(hits, hit)
+		}
+	}
+
+	sortHits(hits, params.Sort, origin)
+
+	result := &models.SearchResult{
+		Jobs:   []*models.Job{},
+		Total:  int64(len(hits)),
+		Facets: memoryFacets(params.Facets, candidates, filters),
+	}
+
+	end := min(offset+params.PageSize, len(hits))
+	for _, hit := range hits[min(offset, len(hits)):end] {
+		job := cloneJob(hit.stored.job)
+		job.Version = localVersion(hit.stored.seqNo)
+		job.Score = hit.score
+		job.MatchedSkills = hit.matchedSkills
+		if origin != nil && job.Geo != nil {
+			job.DistanceKm = geo.DistanceKm(*origin, *job.Geo)
+		}
+		result.Jobs = append(result.Jobs, job)
+	}
+
+	if end < len(hits) {
+		next := pageToken{
+			PitID:       memoryPitID,
+			SearchAfter: []json.RawMessage{json.RawMessage(strconv.Itoa(end))},
+		}
+		var err error
+		if next.Query, err = searchHash(params); err != nil {
+			return nil, err
+		}
+		result.NextPageToken, err = encodePageToken(next)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func decodeMemoryPageToken(raw string, params models.SearchParams) (int, error) {
+	token, err := decodePageToken(raw, params)
+	if err != nil {
+		return 0, err
+	}
+	if token.PitID != memoryPitID {
+		return 0, ErrInvalidPageToken
+	}
+
+	offset, err := strconv.Atoi(string(token.SearchAfter[0]))
+	if err != nil || offset < 0 {
+		return 0, ErrInvalidPageToken
+	}
+	return offset, nil
+}
+
+// Suggest completes titles and companies whose words start with the words of
+// prefix, and skills starting with prefix. Suggestions are ranked by the
+// number of jobs using them.
+func (r *MemoryJobRepository) Suggest(ctx context.Context, prefix string, field models.SuggestField, size int) ([]models.Suggestion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	words := tokenize(prefix)
+	counts := map[string]int{}
+	for _, job := range r.jobs {
+		switch field {
+		case models.SuggestTitle, models.SuggestCompany:
+			value := job.Title
+			if field == models.SuggestCompany {
+				value = job.Company
+			}
+			if value != "" && completes(tokenize(value), words) {
+				counts[value]++
+			}
+		case models.SuggestSkill:
+			for _, skill := range job.Skills {
+				if strings.HasPrefix(strings.ToLower(skill), strings.ToLower(prefix)) {
+					counts[skill]++
+				}
+			}
+		default:
+			return nil, fmt.Errorf("unsupported suggestion field %q", field)
+		}
+	}
+
+	return rankSuggestions(counts, size), nil
+}
+
+// rankSuggestions orders suggestions by the number of jobs using them and
+// keeps the first size.
+func rankSuggestions(counts map[string]int, size int) []models.Suggestion {
+	suggestions := make([]models.Suggestion, 0, len(counts))
+	for text, count := range counts {
+		suggestions = append(suggestions, models.Suggestion{Text: text, Score: float64(count)})
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Text < suggestions[j].Text
+	})
+	if len(suggestions) > size {
+		suggestions = suggestions[:size]
+	}
+	return suggestions
+}
+
+// completes reports whether every word but the last occurs in tokens and the
+// last one starts a token, like a bool_prefix query.
+func completes(tokens, words []string) bool {
+	if len(words) == 0 {
+		return false
+	}
+	for i, word := range words {
+		found := false
+		for _, token := range tokens {
+			if token == word || (i == len(words)-1 && strings.HasPrefix(token, word)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,556 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"job-search-service/internal/models"
+	"job-search-service/internal/taxonomy"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testTaxonomy(t *testing.T) *taxonomy.Taxonomy {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "skills.yaml")
+	data := []byte("skills:\n  - name: Go\n    aliases: [golang]\n  - name: Kubernetes\n    aliases: [k8s]\n")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	skills, err := taxonomy.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return skills
+}
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2026, month, d, 12, 0, 0, 0, time.UTC)
+}
+
+func offset(minutes int) *int {
+	return &minutes
+}
+
+func amountOf(value float64) *float64 {
+	return &value
+}
+
+// testJobs are stored with IDs a to e.
+var testJobs = []models.Job{
+	{
+		ID: "a", Title: "Backend Engineer", Company: "Acme", Location: "Berlin",
+		Skills:          []string{"Go", "Kubernetes", "PostgreSQL"},
+		AnnualSalaryMin: 60000, AnnualSalaryMax: 80000,
+		EmploymentType: models.EmploymentFullTime, WorkMode: models.WorkModeOnsite,
+		CreatedAt: day(time.January, 10),
+	},
+	{
+		ID: "b", Title: "Frontend Developer", Company: "Globex", Location: "Munich",
+		Skills:          []string{"JavaScript", "TypeScript"},
+		AnnualSalaryMin: 50000, AnnualSalaryMax: 55000,
+		EmploymentType: models.EmploymentFullTime, WorkMode: models.WorkModeHybrid,
+		CreatedAt: day(time.February, 10),
+	},
+	{
+		ID: "c", Title: "Platform Engineer", Company: "Initech", Location: "Remote",
+		Skills:          []string{"Go", "Kubernetes"},
+		AnnualSalaryMin: 90000, AnnualSalaryMax: 110000,
+		EmploymentType: models.EmploymentContract, WorkMode: models.WorkModeRemote,
+		Remote: &models.RemotePolicy{
+			AllowedCountries:    []string{"DE", "AT"},
+			MinUTCOffsetMinutes: offset(-60),
+			MaxUTCOffsetMinutes: offset(180),
+		},
+		CreatedAt: day(time.March, 10),
+	},
+	{
+		ID: "d", Title: "Data Scientist", Company: "Umbrella", Location: "Remote",
+		Skills:         []string{"Python"},
+		EmploymentType: models.EmploymentFullTime, WorkMode: models.WorkModeRemote,
+		CreatedAt: day(time.April, 10),
+	},
+	{
+		ID: "e", Title: "Go Developer", Company: "Hooli", Location: "Hamburg",
+		Skills:          []string{"Go"},
+		AnnualSalaryMin: 70000, AnnualSalaryMax: 75000,
+		EmploymentType: models.EmploymentPartTime, WorkMode: models.WorkModeOnsite,
+		CreatedAt: day(time.May, 10),
+	},
+}
+
+func newTestStore(t *testing.T) *MemoryJobRepository {
+	t.Helper()
+
+	repo := NewMemoryJobRepository(testTaxonomy(t))
+	for _, job := range testJobs {
+		job := job
+		if err := repo.Create(context.Background(), &job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return repo
+}
+
+func jobIDs(jobs []*models.Job) []string {
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
+
+func TestMemorySearchFilters(t *testing.T) {
+	repo := newTestStore(t)
+
+	tests := []struct {
+		name   string
+		params models.SearchParams
+		want   []string
+	}{
+		{name: "everything", want: []string{"a", "b", "c", "d", "e"}},
+		{
+			name:   "any skill",
+			params: models.SearchParams{Skills: []string{"Go", "Python"}},
+			want:   []string{"a", "c", "d", "e"},
+		},
+		{
+			name:   "skill alias",
+			params: models.SearchParams{Skills: []string{"golang"}},
+			want:   []string{"a", "c", "e"},
+		},
+		{
+			name:   "all skills",
+			params: models.SearchParams{Skills: []string{"Go", "Kubernetes"}, SkillsMatch: models.SkillsMatchAll},
+			want:   []string{"a", "c"},
+		},
+		{
+			name: "at least two skills",
+			params: models.SearchParams{
+				Skills:             []string{"Go", "k8s", "PostgreSQL", "Python"},
+				SkillsMatch:        models.SkillsMatchAtLeast,
+				SkillsMinimumMatch: 2,
+			},
+			want: []string{"a", "c"},
+		},
+		{
+			name:   "minimum salary",
+			params: models.SearchParams{MinSalary: amountOf(85000)},
+			want:   []string{"c"},
+		},
+		{
+			name:   "maximum salary",
+			params: models.SearchParams{MaxSalary: amountOf(56000)},
+			want:   []string{"b"},
+		},
+		{
+			name:   "salary range overlap",
+			params: models.SearchParams{MinSalary: amountOf(74000), MaxSalary: amountOf(76000)},
+			want:   []string{"a", "e"},
+		},
+		{
+			name:   "remote country not allowed",
+			params: models.SearchParams{RemoteCountry: "US"},
+			want:   []string{"a", "b", "d", "e"},
+		},
+		{
+			name:   "remote country allowed",
+			params: models.SearchParams{RemoteCountry: "DE"},
+			want:   []string{"a", "b", "c", "d", "e"},
+		},
+		{
+			name:   "remote time zone outside policy",
+			params: models.SearchParams{RemoteUTCOffsetMinutes: offset(-300)},
+			want:   []string{"a", "b", "d", "e"},
+		},
+		{
+			name:   "remote time zone inside policy",
+			params: models.SearchParams{RemoteUTCOffsetMinutes: offset(60)},
+			want:   []string{"a", "b", "c", "d", "e"},
+		},
+		{
+			name:   "work mode",
+			params: models.SearchParams{WorkModes: []models.WorkMode{models.WorkModeRemote}},
+			want:   []string{"c", "d"},
+		},
+		{
+			name:   "employment type",
+			params: models.SearchParams{EmploymentTypes: []models.EmploymentType{models.EmploymentFullTime}},
+			want:   []string{"a", "b", "d"},
+		},
+		{
+			name:   "location",
+			params: models.SearchParams{Location: "berlin"},
+			want:   []string{"a"},
+		},
+		{
+			// Like the Elasticsearch filters, location and skills are not
+			// fuzzy; only free text is.
+			name:   "misspelled location",
+			params: models.SearchParams{Location: "Berlim"},
+			want:   []string{},
+		},
+		{
+			name:   "misspelled skill",
+			params: models.SearchParams{Skills: []string{"Pyton"}},
+			want:   []string{},
+		},
+		{
+			name:   "misspelled free text",
+			params: models.SearchParams{Query: "enginer"},
+			want:   []string{"a", "c"},
+		},
+		{
+			name:   "posted after",
+			params: models.SearchParams{PostedAfter: day(time.March, 1)},
+			want:   []string{"c", "d", "e"},
+		},
+		{
+			name:   "free text",
+			params: models.SearchParams{Query: "engineer"},
+			want:   []string{"a", "c"},
+		},
+		{
+			name:   "negated text",
+			params: models.SearchParams{Query: "engineer -platform"},
+			want:   []string{"a"},
+		},
+		{
+			name:   "query language fields",
+			params: models.SearchParams{Query: "skills:go salary:>76000"},
+			want:   []string{"a", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			params.PageSize = 10
+
+			result, err := repo.Search(context.Background(), params)
+			if err != nil {
+				t.Fatalf("Search returned error: %v", err)
+			}
+			got := jobIDs(result.Jobs)
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search returned %v, want %v", got, tt.want)
+			}
+			if result.Total != int64(len(tt.want)) {
+				t.Errorf("Total = %d, want %d", result.Total, len(tt.want))
+			}
+
+			// The in-memory filters must exclude the same facets from
+			// facet counts as the Elasticsearch ones.
+			var esFacets, memoryFacets []models.FacetField
+			for _, filter := range searchFilters(params) {
+				esFacets = append(esFacets, filter.facet)
+			}
+			for _, filter := range newMemoryMatcher(params, repo.skills).filters() {
+				memoryFacets = append(memoryFacets, filter.facet)
+			}
+			if !reflect.DeepEqual(esFacets, memoryFacets) {
+				t.Errorf("memory filter facets %v, Elasticsearch filter facets %v", memoryFacets, esFacets)
+			}
+		})
+	}
+}
+
+func TestMemorySearchMatchedSkills(t *testing.T) {
+	repo := newTestStore(t)
+
+	result, err := repo.Search(context.Background(), models.SearchParams{
+		Skills:   []string{"Kubernetes", "Go"},
+		PageSize: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Jobs with more of the requested skills score higher.
+	if got := jobIDs(result.Jobs); !reflect.DeepEqual(got, []string{"a", "c", "e"}) {
+		t.Fatalf("Search returned %v, want [a c e]", got)
+	}
+	if got := result.Jobs[0].MatchedSkills; !reflect.DeepEqual(got, []string{"Kubernetes", "Go"}) {
+		t.Errorf("MatchedSkills = %v, want [Kubernetes Go]", got)
+	}
+	if result.Jobs[0].Score <= result.Jobs[2].Score {
+		t.Errorf("score of a (%v) not above score of e (%v)", result.Jobs[0].Score, result.Jobs[2].Score)
+	}
+}
+
+func TestMemoryFacets(t *testing.T) {
+	repo := newTestStore(t)
+
+	result, err := repo.Search(context.Background(), models.SearchParams{
+		Skills:          []string{"Go"},
+		EmploymentTypes: []models.EmploymentType{models.EmploymentFullTime},
+		Facets: []models.FacetRequest{
+			{Field: models.FacetSkills, Size: 10},
+			{Field: models.FacetEmploymentType, Size: 10},
+		},
+		PageSize: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := jobIDs(result.Jobs); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("Search returned %v, want [a]", got)
+	}
+
+	// Each facet is counted with every filter except its own.
+	want := map[models.FacetField][]models.FacetBucket{
+		models.FacetSkills: {
+			{Key: "Go", Count: 1},
+			{Key: "JavaScript", Count: 1},
+			{Key: "Kubernetes", Count: 1},
+			{Key: "PostgreSQL", Count: 1},
+			{Key: "Python", Count: 1},
+			{Key: "TypeScript", Count: 1},
+		},
+		models.FacetEmploymentType: {
+			{Key: "contract", Count: 1},
+			{Key: "full_time", Count: 1},
+			{Key: "part_time", Count: 1},
+		},
+	}
+	if !reflect.DeepEqual(result.Facets, want) {
+		t.Errorf("Facets = %v, want %v", result.Facets, want)
+	}
+}
+
+func TestMemorySort(t *testing.T) {
+	repo := newTestStore(t)
+
+	tests := []struct {
+		name string
+		sort []models.SortOption
+		want []string
+	}{
+		{
+			name: "salary descending, missing last",
+			sort: []models.SortOption{{Field: models.SortBySalary, Descending: true}},
+			want: []string{"c", "a", "e", "b", "d"},
+		},
+		{
+			name: "salary ascending, missing last",
+			sort: []models.SortOption{{Field: models.SortBySalary}},
+			want: []string{"b", "e", "a", "c", "d"},
+		},
+		{
+			name: "title",
+			sort: []models.SortOption{{Field: models.SortByTitle}},
+			want: []string{"a", "d", "b", "e", "c"},
+		},
+		{
+			name: "newest first",
+			sort: []models.SortOption{{Field: models.SortByCreatedAt, Descending: true}},
+			want: []string{"e", "d", "c", "b", "a"},
+		},
+		{
+			name: "equal scores by ID",
+			want: []string{"a", "b", "c", "d", "e"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := repo.Search(context.Background(), models.SearchParams{Sort: tt.sort, PageSize: 10})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := jobIDs(result.Jobs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search returned %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryPageTokens(t *testing.T) {
+	repo := newTestStore(t)
+	ctx := context.Background()
+	params := models.SearchParams{
+		Sort:     []models.SortOption{{Field: models.SortByCreatedAt}},
+		PageSize: 2,
+	}
+
+	var pages [][]string
+	for {
+		result, err := repo.Search(ctx, params)
+		if err != nil {
+			t.Fatalf("page %d: %v", len(pages)+1, err)
+		}
+		pages = append(pages, jobIDs(result.Jobs))
+		if result.NextPageToken == "" {
+			break
+		}
+		params.PageToken = result.NextPageToken
+	}
+
+	want := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}
+	if !reflect.DeepEqual(pages, want) {
+		t.Fatalf("pages = %v, want %v", pages, want)
+	}
+
+	first, err := repo.Search(ctx, models.SearchParams{
+		Sort:     []models.SortOption{{Field: models.SortByCreatedAt}},
+		PageSize: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The page size may change between pages.
+	next, err := repo.Search(ctx, models.SearchParams{
+		Sort:      []models.SortOption{{Field: models.SortByCreatedAt}},
+		PageSize:  3,
+		PageToken: first.NextPageToken,
+	})
+	if err != nil {
+		t.Fatalf("page with another size: %v", err)
+	}
+	if got := jobIDs(next.Jobs); !reflect.DeepEqual(got, []string{"c", "d", "e"}) {
+		t.Errorf("page with another size returned %v, want [c d e]", got)
+	}
+
+	elasticToken, err := encodePageToken(pageToken{
+		PitID:       "pit",
+		SearchAfter: []json.RawMessage{json.RawMessage("2")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := []struct {
+		name   string
+		params models.SearchParams
+	}{
+		{
+			name:   "malformed",
+			params: models.SearchParams{PageToken: "not a token"},
+		},
+		{
+			name:   "issued by Elasticsearch",
+			params: models.SearchParams{PageToken: elasticToken},
+		},
+		{
+			name: "changed query",
+			params: models.SearchParams{
+				Query:     "engineer",
+				Sort:      []models.SortOption{{Field: models.SortByCreatedAt}},
+				PageToken: first.NextPageToken,
+			},
+		},
+		{
+			name: "changed filters",
+			params: models.SearchParams{
+				Skills:    []string{"Go"},
+				Sort:      []models.SortOption{{Field: models.SortByCreatedAt}},
+				PageToken: first.NextPageToken,
+			},
+		},
+		{
+			name:   "changed sort",
+			params: models.SearchParams{PageToken: first.NextPageToken},
+		},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			params.PageSize = 2
+			if _, err := repo.Search(ctx, params); !errors.Is(err, ErrInvalidPageToken) {
+				t.Errorf("Search error = %v, want ErrInvalidPageToken", err)
+			}
+		})
+	}
+}
+
+func TestMemoryVersions(t *testing.T) {
+	repo := newTestStore(t)
+	ctx := context.Background()
+
+	job, err := repo.GetByID(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := job.Version
+
+	job.Title = "Senior Backend Engineer"
+	if err := repo.Update(ctx, job, stale); err != nil {
+		t.Fatalf("Update with the current version: %v", err)
+	}
+	if job.Version == stale {
+		t.Errorf("Update kept version %s", stale)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{
+			name: "update with a stale version",
+			call: func() error { return repo.Update(ctx, job, stale) },
+			want: ErrVersionConflict,
+		},
+		{
+			name: "delete with a stale version",
+			call: func() error { return repo.Delete(ctx, "a", stale) },
+			want: ErrVersionConflict,
+		},
+		{
+			name: "update with a version from another index generation",
+			call: func() error { return repo.Update(ctx, job, "2"+strings.TrimPrefix(job.Version, "0")) },
+			want: ErrVersionConflict,
+		},
+		{
+			name: "update with a malformed version",
+			call: func() error { return repo.Update(ctx, job, "latest") },
+			want: ErrInvalidVersion,
+		},
+		{
+			name: "update with a version without a generation",
+			call: func() error { return repo.Update(ctx, job, "1.2") },
+			want: ErrInvalidVersion,
+		},
+		{
+			name: "update of a missing job",
+			call: func() error { return repo.Update(ctx, &models.Job{ID: "missing"}, "") },
+			want: ErrJobNotFound,
+		},
+		{
+			name: "get of a missing job",
+			call: func() error { _, err := repo.GetByID(ctx, "missing"); return err },
+			want: ErrJobNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	stored, err := repo.GetByID(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Title != "Senior Backend Engineer" || stored.Version != job.Version {
+		t.Errorf("stored job has title %q and version %s, want the update with version %s", stored.Title, stored.Version, job.Version)
+	}
+
+	if err := repo.Delete(ctx, "a", job.Version); err != nil {
+		t.Fatalf("Delete with the current version: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "a"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("GetByID after Delete error = %v, want ErrJobNotFound", err)
+	}
+}
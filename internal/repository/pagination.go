@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"job-search-service/internal/errs"
+	"job-search-service/internal/models"
+)
+
+// pitKeepAlive is how long Elasticsearch keeps a point-in-time open between
+// two page requests. Each page request extends it again. A point-in-time is
+// only opened once a search has a next page, and one the client stops paging
+// through holds its search context until it expires.
+const pitKeepAlive = "1m"
+
+var ErrInvalidPageToken = &errs.Error{
+	Kind:   errs.InvalidArgument,
+	Reason: "INVALID_PAGE_TOKEN",
+	Field:  "page_token",
+	Msg:    "invalid page token",
+}
+
+// pageToken is the decoded form of the opaque token handed to clients. It pins
+// the point-in-time later pages are served from and the sort values of the
+// last hit so the next page can continue with search_after. Query is the
+// searchHash of the request the token was issued for. Now is the time the
+// first page measured recency from, in Unix milliseconds, so that scores do
+// not drift between pages.
+type pageToken struct {
+	PitID       string            `json:"pit"`
+	SearchAfter []json.RawMessage `json:"after"`
+	Query       string            `json:"q"`
+	Now         int64             `json:"now,omitempty"`
+}
+
+func encodePageToken(token pageToken) (string, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", fmt.Errorf("error encoding page token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken decodes raw and checks that it was issued for a search
+// with the same searchHash as params.
+func decodePageToken(raw string, params models.SearchParams) (pageToken, error) {
+	var token pageToken
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return token, ErrInvalidPageToken
+	}
+	if err := json.Unmarshal(data, &token); err != nil || token.PitID == "" || len(token.SearchAfter) == 0 {
+		return token, ErrInvalidPageToken
+	}
+
+	hash, err := searchHash(params)
+	if err != nil {
+		return token, err
+	}
+	if token.Query != hash {
+		return token, fmt.Errorf("%w: query or filters changed between pages", ErrInvalidPageToken)
+	}
+
+	return token, nil
+}
+
+// searchHash identifies the query, filters, sort and ranking of params so
+// that a page token cannot be replayed against a different search. Options
+// that do not change which jobs are returned, or in which order, are left out.
+func searchHash(params models.SearchParams) (string, error) {
+	params.PageToken = ""
+	params.PageSize = 0
+	params.Highlight = nil
+	params.Explain = false
+	params.AutoCorrect = false
+	params.Facets = nil
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return "", fmt.Errorf("error hashing search: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:12]), nil
+}
+
+func (r *JobRepository) openPointInTime(ctx context.Context) (string, error) {
+	res, err := r.client.OpenPointInTime(
+		[]string{r.indexName},
+		pitKeepAlive,
+		r.client.OpenPointInTime.WithContext(ctx),
+	)
+	if err != nil {
+		return "", requestError("error opening point in time", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", responseError("error opening point in time", res)
+	}
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("error parsing point in time response: %w", err)
+	}
+
+	return result.ID, nil
+}
+
+// closePointInTime releases the search context once the last page has been
+// served. Failures are not fatal since the context expires on its own.
+func (r *JobRepository) closePointInTime(ctx context.Context, pitID string) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"id": pitID,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding point in time: %w", err)
+	}
+
+	res, err := r.client.ClosePointInTime(
+		r.client.ClosePointInTime.WithContext(ctx),
+		r.client.ClosePointInTime.WithBody(bytes.NewReader(body)),
+	)
+	if err != nil {
+		return fmt.Errorf("error closing point in time: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != 404 {
+		return fmt.Errorf("error closing point in time: %s", res.String())
+	}
+
+	return nil
+}
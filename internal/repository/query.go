@@ -0,0 +1,534 @@
+package repository
+
+import (
+	"job-search-service/internal/models"
+	"job-search-service/internal/querylang"
+	"job-search-service/internal/ranking"
+	"strconv"
+	"time"
+)
+
+// searchFilter is one constraint from the search request. Filters marked as
+// scoring contribute to relevance; the others only narrow the result set.
+// Filters without a facet apply to every facet count.
+type searchFilter struct {
+	facet   models.FacetField
+	scoring bool
+	clause  map[string]interface{}
+}
+
+// buildQuery returns the query, post_filter and aggregations sections of the
+// search body for params and its parsed query string, measuring recency from
+// now. Without facets every filter is part of the query. With facets the
+// filters move to post_filter and each facet is aggregated with all filters
+// except its own, so selecting a value does not hide the other values of the
+// same facet. Scores and therefore the order of results are the same either
+// way.
+func buildQuery(params models.SearchParams, parsed querylang.Node, now time.Time) (query, postFilter, aggs map[string]interface{}) {
+	mustQueries := []interface{}{}
+	filterQueries := []interface{}{}
+
+	profile := params.Ranking
+	if profile == nil {
+		profile = &ranking.Legacy
+	}
+
+	if parsed != nil {
+		translator := querylang.Translator{Text: textQueryFor(profile.Fields())}
+		mustQueries = append(mustQueries, translator.Translate(parsed))
+	}
+	shouldQueries := skillScoring(params.Skills)
+
+	filters := searchFilters(params)
+
+	if len(params.Facets) == 0 {
+		for _, filter := range filters {
+			if filter.scoring {
+				mustQueries = append(mustQueries, filter.clause)
+			} else {
+				filterQueries = append(filterQueries, filter.clause)
+			}
+		}
+	} else {
+		aggs = buildAggregations(params.Facets, filters)
+		if len(filters) > 0 {
+			postFilter = filterClause(filters, "")
+		}
+		// post_filter does not score, so scoring filters also go into the
+		// query as optional clauses. Jobs that fail them are dropped by
+		// post_filter; the others score as they would without facets.
+		for _, filter := range filters {
+			if filter.scoring {
+				shouldQueries = append(shouldQueries, filter.clause)
+			}
+		}
+	}
+
+	if len(mustQueries) == 0 && len(filterQueries) == 0 && len(shouldQueries) == 0 {
+		query = map[string]interface{}{
+			"match_all": map[string]interface{}{},
+		}
+	} else {
+		query = map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must":   mustQueries,
+				"filter": filterQueries,
+				// Skills, and scoring filters moved to post_filter, are
+				// enforced by their filters; here they only add to the
+				// score.
+				"should":               shouldQueries,
+				"minimum_should_match": 0,
+			},
+		}
+	}
+
+	return withRanking(query, profile, now), postFilter, aggs
+}
+
+// textQueryFor returns a querylang.Translator.Text function searching the
+// given boosted fields.
+func textQueryFor(textFields []string) func(string, bool) map[string]interface{} {
+	return func(query string, exact bool) map[string]interface{} {
+		return textQuery(query, textFields, exact)
+	}
+}
+
+// textQuery searches free text in title, description and company. Exact text
+// only matches as a phrase; otherwise misspellings and partial words match
+// too.
+func textQuery(query string, textFields []string, exact bool) map[string]interface{} {
+	if exact {
+		return map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":  query,
+				"type":   "phrase",
+				"fields": textFields,
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should": []interface{}{
+				map[string]interface{}{
+					"multi_match": map[string]interface{}{
+						"query":     query,
+						"fields":    textFields,
+						"fuzziness": "AUTO",
+					},
+				},
+				substringQuery("title.ngram", query),
+				substringQuery("company.ngram", query),
+				map[string]interface{}{
+					"multi_match": map[string]interface{}{
+						"query": query,
+						"type":  "bool_prefix",
+						"fields": []string{
+							"title.suggest", "title.suggest._2gram", "title.suggest._3gram",
+							"company.suggest", "company.suggest._2gram", "company.suggest._3gram",
+						},
+					},
+				},
+			},
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+// substringQuery matches text anywhere inside a field through its trigram
+// subfield, so "stack" finds "Fullstack Engineer" without a wildcard scan. It
+// scores like a filter so long fields do not outrank real matches; text
+// shorter than three characters matches nothing.
+func substringQuery(field, text string) map[string]interface{} {
+	return map[string]interface{}{
+		"constant_score": map[string]interface{}{
+			"filter": map[string]interface{}{
+				"match": map[string]interface{}{
+					field: map[string]interface{}{
+						"query":    text,
+						"operator": "and",
+					},
+				},
+			},
+		},
+	}
+}
+
+func searchFilters(params models.SearchParams) []searchFilter {
+	filters := []searchFilter{}
+
+	if params.Location != "" {
+		filters = append(filters, searchFilter{
+			facet:   models.FacetLocation,
+			scoring: true,
+			clause: map[string]interface{}{
+				"bool": map[string]interface{}{
+					"should": []interface{}{
+						map[string]interface{}{
+							"match": map[string]interface{}{
+								"location": params.Location,
+							},
+						},
+						substringQuery("location.ngram", params.Location),
+					},
+					"minimum_should_match": 1,
+				},
+			},
+		})
+	}
+
+	if len(params.Skills) > 0 {
+		skills := make([]interface{}, 0, len(params.Skills))
+		for _, skill := range params.Skills {
+			skills = append(skills, skillQuery(skill))
+		}
+		filters = append(filters, searchFilter{
+			facet: models.FacetSkills,
+			clause: map[string]interface{}{
+				"bool": map[string]interface{}{
+					"should":               skills,
+					"minimum_should_match": skillsMinimumMatch(params),
+				},
+			},
+		})
+	}
+
+	// The requested salary range matches jobs whose annual range overlaps it.
+	if params.MinSalary != nil || params.MaxSalary != nil {
+		overlap := []interface{}{}
+		if params.MinSalary != nil {
+			overlap = append(overlap, map[string]interface{}{
+				"range": map[string]interface{}{
+					"annual_salary_max": map[string]interface{}{"gte": *params.MinSalary},
+				},
+			})
+		}
+		if params.MaxSalary != nil {
+			overlap = append(overlap, map[string]interface{}{
+				"range": map[string]interface{}{
+					"annual_salary_min": map[string]interface{}{"lte": *params.MaxSalary},
+				},
+			})
+		}
+		filters = append(filters, searchFilter{
+			facet: models.FacetSalary,
+			clause: map[string]interface{}{
+				"bool": map[string]interface{}{
+					"filter": overlap,
+				},
+			},
+		})
+	}
+
+	if len(params.EmploymentTypes) > 0 {
+		filters = append(filters, searchFilter{
+			facet: models.FacetEmploymentType,
+			clause: map[string]interface{}{
+				"terms": map[string]interface{}{
+					"employment_type": params.EmploymentTypes,
+				},
+			},
+		})
+	}
+
+	if len(params.Seniorities) > 0 {
+		filters = append(filters, searchFilter{
+			facet: models.FacetSeniority,
+			clause: map[string]interface{}{
+				"terms": map[string]interface{}{
+					"seniority": params.Seniorities,
+				},
+			},
+		})
+	}
+
+	if !params.PostedAfter.IsZero() || !params.PostedBefore.IsZero() {
+		createdAt := map[string]interface{}{}
+		if !params.PostedAfter.IsZero() {
+			createdAt["gte"] = params.PostedAfter.UTC().Format(time.RFC3339Nano)
+		}
+		if !params.PostedBefore.IsZero() {
+			createdAt["lt"] = params.PostedBefore.UTC().Format(time.RFC3339Nano)
+		}
+		filters = append(filters, searchFilter{
+			facet: models.FacetCreatedAt,
+			clause: map[string]interface{}{
+				"range": map[string]interface{}{
+					"created_at": createdAt,
+				},
+			},
+		})
+	}
+
+	if near := params.Near; near != nil && near.Origin != nil && near.RadiusKm > 0 {
+		filters = append(filters, searchFilter{
+			clause: map[string]interface{}{
+				"geo_distance": map[string]interface{}{
+					"distance": strconv.FormatFloat(near.RadiusKm, 'f', -1, 64) + "km",
+					"geo": map[string]interface{}{
+						"lat": near.Origin.Lat,
+						"lon": near.Origin.Lon,
+					},
+				},
+			},
+		})
+	}
+
+	if len(params.WorkModes) > 0 {
+		filters = append(filters, searchFilter{
+			clause: map[string]interface{}{
+				"terms": map[string]interface{}{
+					"work_mode": params.WorkModes,
+				},
+			},
+		})
+	}
+
+	if params.RemoteCountry != "" {
+		filters = append(filters, searchFilter{
+			clause: unlessRemote(map[string]interface{}{
+				"bool": map[string]interface{}{
+					"should": []interface{}{
+						map[string]interface{}{
+							"term": map[string]interface{}{
+								"remote_policy.allowed_countries": params.RemoteCountry,
+							},
+						},
+						missing("remote_policy.allowed_countries"),
+					},
+					"minimum_should_match": 1,
+				},
+			}),
+		})
+	}
+
+	if offset := params.RemoteUTCOffsetMinutes; offset != nil {
+		filters = append(filters, searchFilter{
+			clause: unlessRemote(map[string]interface{}{
+				"bool": map[string]interface{}{
+					"filter": []interface{}{
+						map[string]interface{}{
+							"bool": map[string]interface{}{
+								"should": []interface{}{
+									map[string]interface{}{
+										"range": map[string]interface{}{
+											"remote_policy.min_utc_offset_minutes": map[string]interface{}{"lte": *offset},
+										},
+									},
+									missing("remote_policy.min_utc_offset_minutes"),
+								},
+								"minimum_should_match": 1,
+							},
+						},
+						map[string]interface{}{
+							"bool": map[string]interface{}{
+								"should": []interface{}{
+									map[string]interface{}{
+										"range": map[string]interface{}{
+											"remote_policy.max_utc_offset_minutes": map[string]interface{}{"gte": *offset},
+										},
+									},
+									missing("remote_policy.max_utc_offset_minutes"),
+								},
+								"minimum_should_match": 1,
+							},
+						},
+					},
+				},
+			}),
+		})
+	}
+
+	return filters
+}
+
+// skillQuery matches a single skill. skills.text expands it to all of its
+// spellings from the taxonomy, so jobs indexed under an alias still match.
+func skillQuery(skill string) map[string]interface{} {
+	return map[string]interface{}{
+		"match": map[string]interface{}{
+			"skills.text": skill,
+		},
+	}
+}
+
+func skillsMinimumMatch(params models.SearchParams) int {
+	switch params.SkillsMatch {
+	case models.SkillsMatchAll:
+		return len(params.Skills)
+	case models.SkillsMatchAtLeast:
+		return min(max(params.SkillsMinimumMatch, 1), len(params.Skills))
+	default:
+		return 1
+	}
+}
+
+const (
+	// skillMatchBoost is added to the score of a job for every requested
+	// skill it has.
+	skillMatchBoost = 1.0
+	// skillQueryPrefix marks the named queries reporting matched skills.
+	skillQueryPrefix = "skill:"
+)
+
+// skillScoring returns one named clause per skill so that every matched skill
+// raises the score by the same amount and shows up in matched_queries.
+func skillScoring(skills []string) []interface{} {
+	clauses := make([]interface{}, 0, len(skills))
+	for _, skill := range skills {
+		clauses = append(clauses, map[string]interface{}{
+			"constant_score": map[string]interface{}{
+				"filter": skillQuery(skill),
+				"boost":  skillMatchBoost,
+				"_name":  skillQueryPrefix + skill,
+			},
+		})
+	}
+	return clauses
+}
+
+// matchedSkills returns the requested skills whose named query a hit matched,
+// in the order they were requested.
+func matchedSkills(requested, matchedQueries []string) []string {
+	matched := make(map[string]bool, len(matchedQueries))
+	for _, name := range matchedQueries {
+		matched[name] = true
+	}
+
+	var skills []string
+	for _, skill := range requested {
+		if matched[skillQueryPrefix+skill] {
+			skills = append(skills, skill)
+		}
+	}
+	return skills
+}
+
+// unlessRemote applies clause to remote jobs only; jobs with any other work
+// mode always match.
+func unlessRemote(clause map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should": []interface{}{
+				map[string]interface{}{
+					"bool": map[string]interface{}{
+						"must_not": map[string]interface{}{
+							"term": map[string]interface{}{
+								"work_mode": models.WorkModeRemote,
+							},
+						},
+					},
+				},
+				clause,
+			},
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+func missing(field string) map[string]interface{} {
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must_not": map[string]interface{}{
+				"exists": map[string]interface{}{
+					"field": field,
+				},
+			},
+		},
+	}
+}
+
+// filterClause combines all filters except the ones belonging to the given
+// facet into a single non-scoring bool query.
+func filterClause(filters []searchFilter, exclude models.FacetField) map[string]interface{} {
+	clauses := []interface{}{}
+	for _, filter := range filters {
+		if exclude != "" && filter.facet == exclude {
+			continue
+		}
+		clauses = append(clauses, filter.clause)
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"filter": clauses,
+		},
+	}
+}
+
+func buildAggregations(facets []models.FacetRequest, filters []searchFilter) map[string]interface{} {
+	aggs := make(map[string]interface{}, len(facets))
+
+	for _, facet := range facets {
+		values := facetAggregation(facet)
+		if values == nil {
+			continue
+		}
+		aggs[facetAggregationPrefix+string(facet.Field)] = map[string]interface{}{
+			"filter": filterClause(filters, facet.Field),
+			"aggs": map[string]interface{}{
+				"values": values,
+			},
+		}
+	}
+
+	return aggs
+}
+
+const facetAggregationPrefix = "facet_"
+
+func facetAggregation(facet models.FacetRequest) map[string]interface{} {
+	switch facet.Field {
+	case models.FacetSkills, models.FacetLocation, models.FacetCompany, models.FacetEmploymentType, models.FacetSeniority:
+		field := string(facet.Field)
+		if facet.Field == models.FacetLocation || facet.Field == models.FacetCompany {
+			field += ".keyword"
+		}
+		return map[string]interface{}{
+			"terms": map[string]interface{}{
+				"field": field,
+				"size":  facet.Size,
+			},
+		}
+	case models.FacetSalary:
+		return map[string]interface{}{
+			"histogram": map[string]interface{}{
+				"field":         "annual_salary_min",
+				"interval":      facet.Interval,
+				"min_doc_count": 1,
+			},
+		}
+	case models.FacetCreatedAt:
+		return map[string]interface{}{
+			"date_histogram": map[string]interface{}{
+				"field":             "created_at",
+				"calendar_interval": facet.CalendarInterval,
+				"format":            "yyyy-MM-dd",
+				"min_doc_count":     1,
+			},
+		}
+	default:
+		return nil
+	}
+}
+
+// buildHighlight highlights title and company as a whole and returns the best
+// description fragments.
+func buildHighlight(options *models.HighlightOptions) map[string]interface{} {
+	return map[string]interface{}{
+		"pre_tags":            []string{options.PreTag},
+		"post_tags":           []string{options.PostTag},
+		"fragment_size":       options.FragmentSize,
+		"number_of_fragments": options.NumberOfFragments,
+		"require_field_match": false,
+		"fields": map[string]interface{}{
+			"title": map[string]interface{}{
+				"number_of_fragments": 0,
+			},
+			"company": map[string]interface{}{
+				"number_of_fragments": 0,
+			},
+			"description": map[string]interface{}{},
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"encoding/json"
+	"job-search-service/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildQueryScoresWithFacets(t *testing.T) {
+	params := models.SearchParams{Query: "engineer", Location: "berlin", Skills: []string{"Go"}}
+	withFacets := params
+	withFacets.Facets = []models.FacetRequest{{Field: models.FacetLocation, Size: 10}}
+
+	now := time.Now()
+	plain, plainFilter, _ := buildQuery(params, nil, now)
+	faceted, facetedFilter, aggs := buildQuery(withFacets, nil, now)
+
+	if plainFilter != nil {
+		t.Errorf("post_filter = %v without facets, want none", plainFilter)
+	}
+	if facetedFilter == nil || aggs == nil {
+		t.Fatalf("post_filter = %v and aggregations = %v with facets, want both", facetedFilter, aggs)
+	}
+
+	// The location filter scores, so it must stay in the query either way.
+	for name, query := range map[string]map[string]interface{}{"without facets": plain, "with facets": faceted} {
+		data, err := json.Marshal(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), "location.ngram") {
+			t.Errorf("query %s = %s, want the location clause in it", name, data)
+		}
+	}
+	if mustHash(t, params) != mustHash(t, withFacets) {
+		t.Error("facets change the search hash, but they do not change the order of results")
+	}
+}
+
+func mustHash(t *testing.T, params models.SearchParams) string {
+	t.Helper()
+
+	hash, err := searchHash(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
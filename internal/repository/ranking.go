@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"job-search-service/internal/ranking"
+	"time"
+)
+
+// salaryBoostScript implements ranking.SalaryBoost. Jobs without a salary
+// keep their score.
+const salaryBoostScript = `
+if (doc['annual_salary_max'].size() == 0) {
+	return 1;
+}
+return 1 + params.weight * Math.log1p(doc['annual_salary_max'].value / params.scale);
+`
+
+// withRanking wraps query in a function_score applying the recency decay and
+// the salary and featured boosts of profile. All factors multiply the text
+// score, so they reorder similarly good matches rather than letting a fresh
+// posting outrank a much better one. Without any functions the query is
+// returned unchanged. Recency is measured from now instead of Elasticsearch's
+// "now", which every page of a search would evaluate anew.
+func withRanking(query map[string]interface{}, profile *ranking.Profile, now time.Time) map[string]interface{} {
+	functions := []interface{}{}
+
+	if decay := profile.Recency; decay != nil {
+		params := map[string]interface{}{
+			"origin": now.UTC().Format(time.RFC3339Nano),
+			"scale":  decay.Scale,
+			"decay":  decay.Decay,
+		}
+		if decay.Offset != "" {
+			params["offset"] = decay.Offset
+		}
+		functions = append(functions, map[string]interface{}{
+			decay.Function: map[string]interface{}{
+				"created_at": params,
+			},
+		})
+	}
+
+	if salary := profile.Salary; salary != nil && salary.Weight > 0 {
+		functions = append(functions, map[string]interface{}{
+			"script_score": map[string]interface{}{
+				"script": map[string]interface{}{
+					"source": salaryBoostScript,
+					"params": map[string]interface{}{
+						"weight": salary.Weight,
+						"scale":  salary.Scale,
+					},
+				},
+			},
+		})
+	}
+
+	if profile.FeaturedBoost > 0 && profile.FeaturedBoost != 1 {
+		functions = append(functions, map[string]interface{}{
+			"filter": map[string]interface{}{
+				"term": map[string]interface{}{
+					"featured": true,
+				},
+			},
+			"weight": profile.FeaturedBoost,
+		})
+	}
+
+	if len(functions) == 0 {
+		return query
+	}
+
+	return map[string]interface{}{
+		"function_score": map[string]interface{}{
+			"query":      query,
+			"functions":  functions,
+			"score_mode": "multiply",
+			"boost_mode": "multiply",
+		},
+	}
+}
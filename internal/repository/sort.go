@@ -0,0 +1,67 @@
+package repository
+
+import "job-search-service/internal/models"
+
+var sortFields = map[models.SortField]string{
+	models.SortByRelevance: "_score",
+	models.SortByCreatedAt: "created_at",
+	models.SortBySalary:    "annual_salary_max",
+	models.SortByTitle:     "title.keyword",
+	models.SortByCompany:   "company.keyword",
+}
+
+// buildSort translates the requested sort options into an Elasticsearch sort
+// clause. Relevance is added as a tie-breaker when not requested explicitly
+// and the job ID always comes last so that search_after pagination is
+// deterministic. Sorting by distance requires an origin.
+func buildSort(options []models.SortOption, origin *models.GeoPoint) []interface{} {
+	sort := make([]interface{}, 0, len(options)+2)
+	seen := make(map[string]bool, len(options))
+
+	for _, option := range options {
+		if option.Field == models.SortByDistance {
+			if origin == nil || seen["_geo_distance"] {
+				continue
+			}
+			seen["_geo_distance"] = true
+
+			order := "asc"
+			if option.Descending {
+				order = "desc"
+			}
+			sort = append(sort, map[string]interface{}{
+				"_geo_distance": map[string]interface{}{
+					"geo":             map[string]interface{}{"lat": origin.Lat, "lon": origin.Lon},
+					"order":           order,
+					"unit":            "km",
+					"ignore_unmapped": true,
+				},
+			})
+			continue
+		}
+
+		field, ok := sortFields[option.Field]
+		if !ok || seen[field] {
+			continue
+		}
+		seen[field] = true
+
+		order := "asc"
+		if option.Descending {
+			order = "desc"
+		}
+
+		clause := map[string]interface{}{"order": order}
+		if field != "_score" {
+			clause["missing"] = "_last"
+		}
+		sort = append(sort, map[string]interface{}{field: clause})
+	}
+
+	if !seen["_score"] {
+		sort = append(sort, map[string]interface{}{"_score": map[string]interface{}{"order": "desc"}})
+	}
+	sort = append(sort, map[string]interface{}{"id": map[string]interface{}{"order": "asc"}})
+
+	return sort
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"job-search-service/internal/models"
+	"reflect"
+	"testing"
+)
+
+func TestBuildSort(t *testing.T) {
+	origin := &models.GeoPoint{Lat: 52.52, Lon: 13.405}
+	score := func(order string) map[string]interface{} {
+		return map[string]interface{}{"_score": map[string]interface{}{"order": order}}
+	}
+	id := map[string]interface{}{"id": map[string]interface{}{"order": "asc"}}
+
+	tests := []struct {
+		name    string
+		options []models.SortOption
+		origin  *models.GeoPoint
+		want    []interface{}
+	}{
+		{
+			name: "relevance and ID by default",
+			want: []interface{}{score("desc"), id},
+		},
+		{
+			name:    "field with missing values last",
+			options: []models.SortOption{{Field: models.SortBySalary, Descending: true}},
+			want: []interface{}{
+				map[string]interface{}{"annual_salary_max": map[string]interface{}{"order": "desc", "missing": "_last"}},
+				score("desc"),
+				id,
+			},
+		},
+		{
+			name: "explicit relevance is not repeated",
+			options: []models.SortOption{
+				{Field: models.SortByRelevance},
+				{Field: models.SortByTitle},
+				{Field: models.SortByRelevance, Descending: true},
+			},
+			want: []interface{}{
+				score("asc"),
+				map[string]interface{}{"title.keyword": map[string]interface{}{"order": "asc", "missing": "_last"}},
+				id,
+			},
+		},
+		{
+			name:    "distance without origin is ignored",
+			options: []models.SortOption{{Field: models.SortByDistance}},
+			want:    []interface{}{score("desc"), id},
+		},
+		{
+			name:    "distance from origin",
+			options: []models.SortOption{{Field: models.SortByDistance}},
+			origin:  origin,
+			want: []interface{}{
+				map[string]interface{}{"_geo_distance": map[string]interface{}{
+					"geo":             map[string]interface{}{"lat": origin.Lat, "lon": origin.Lon},
+					"order":           "asc",
+					"unit":            "km",
+					"ignore_unmapped": true,
+				}},
+				score("desc"),
+				id,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildSort(tt.options, tt.origin); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildSort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
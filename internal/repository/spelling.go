@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// suggestQuery runs a phrase suggester over the spelling field, which holds
+// the shingled words of every title and skill, and returns the best
+// correction of query that matches at least one job. It returns "" when there
+// is no such correction.
+func (r *JobRepository) suggestQuery(ctx context.Context, query string) (string, error) {
+	body := map[string]interface{}{
+		"size": 0,
+		"suggest": map[string]interface{}{
+			"text": query,
+			"did_you_mean": map[string]interface{}{
+				"phrase": map[string]interface{}{
+					"field":      "spelling",
+					"size":       1,
+					"gram_size":  3,
+					"max_errors": 2,
+					"direct_generator": []interface{}{
+						map[string]interface{}{
+							"field":        "spelling",
+							"suggest_mode": "always",
+						},
+					},
+					"collate": map[string]interface{}{
+						"query": map[string]interface{}{
+							"source": map[string]interface{}{
+								"match": map[string]interface{}{
+									"spelling": map[string]interface{}{
+										"query":    "{{suggestion}}",
+										"operator": "and",
+									},
+								},
+							},
+						},
+						"prune": false,
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return "", fmt.Errorf("error encoding suggestion: %w", err)
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithContext(ctx),
+		r.client.Search.WithIndex(r.indexName),
+		r.client.Search.WithBody(&buf),
+	)
+	if err != nil {
+		return "", fmt.Errorf("error executing suggestion: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", fmt.Errorf("error response: %s", res.String())
+	}
+
+	var result struct {
+		Suggest struct {
+			DidYouMean []struct {
+				Options []struct {
+					Text string `json:"text"`
+				} `json:"options"`
+			} `json:"did_you_mean"`
+		} `json:"suggest"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("error parsing suggestion: %w", err)
+	}
+
+	for _, entry := range result.Suggest.DidYouMean {
+		for _, option := range entry.Options {
+			return option.Text, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"job-search-service/internal/models"
+)
+
+// JobStore stores and searches jobs. JobRepository keeps them in
+// Elasticsearch; MemoryJobRepository keeps them in process memory and
+// FileJobRepository in a local SQLite file, for running the service without a
+// cluster.
+type JobStore interface {
+	// Create stores a new job and sets its Version.
+	Create(ctx context.Context, job *models.Job) error
+	// CreateBatch stores new jobs in one request and sets their Versions.
+	// Unlike Create it does not wait for them to become searchable; call
+	// Refresh once done. The returned slice holds the error of each job that
+	// was not stored at its index; an error fails the whole batch.
+	CreateBatch(ctx context.Context, jobs []*models.Job) ([]error, error)
+	// Refresh makes all writes visible to searches.
+	Refresh(ctx context.Context) error
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	// Update replaces a stored job and sets its new Version. A non-empty
+	// expectedVersion makes the write conditional.
+	Update(ctx context.Context, job *models.Job, expectedVersion string) error
+	Delete(ctx context.Context, id string, expectedVersion string) error
+	Search(ctx context.Context, params models.SearchParams) (*models.SearchResult, error)
+	Suggest(ctx context.Context, prefix string, field models.SuggestField, size int) ([]models.Suggestion, error)
+}
+
+var (
+	_ JobStore = (*JobRepository)(nil)
+	_ JobStore = (*MemoryJobRepository)(nil)
+	_ JobStore = (*FileJobRepository)(nil)
+)
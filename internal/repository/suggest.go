@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"job-search-service/internal/models"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// suggestTimeout bounds the time Elasticsearch spends on a suggestion request.
+// Suggestions are requested on every keystroke, so partial results are
+// preferable to slow ones.
+const suggestTimeout = 100 * time.Millisecond
+
+// Suggest returns up to size completions for prefix. Titles and companies are
+// matched against their search_as_you_type subfields and collapsed so each
+// distinct value is returned once; skills are ranked by how many jobs use
+// them.
+func (r *JobRepository) Suggest(ctx context.Context, prefix string, field models.SuggestField, size int) ([]models.Suggestion, error) {
+	var body map[string]interface{}
+
+	switch field {
+	case models.SuggestTitle, models.SuggestCompany:
+		body = map[string]interface{}{
+			"size":    size,
+			"_source": []string{string(field)},
+			"query": map[string]interface{}{
+				"multi_match": map[string]interface{}{
+					"query": prefix,
+					"type":  "bool_prefix",
+					"fields": []string{
+						string(field) + ".suggest",
+						string(field) + ".suggest._2gram",
+						string(field) + ".suggest._3gram",
+					},
+				},
+			},
+			"collapse": map[string]interface{}{
+				"field": string(field) + ".keyword",
+			},
+		}
+	case models.SuggestSkill:
+		body = map[string]interface{}{
+			"size": 0,
+			"query": map[string]interface{}{
+				"prefix": map[string]interface{}{
+					"skills": map[string]interface{}{
+						"value":            prefix,
+						"case_insensitive": true,
+					},
+				},
+			},
+			"aggs": map[string]interface{}{
+				"skills": map[string]interface{}{
+					"terms": map[string]interface{}{
+						"field":   "skills",
+						"size":    size,
+						"include": caseInsensitivePrefixRegexp(prefix),
+					},
+				},
+			},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported suggestion field %q", field)
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return nil, fmt.Errorf("error encoding query: %w", err)
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithContext(ctx),
+		r.client.Search.WithIndex(r.indexName),
+		r.client.Search.WithBody(&buf),
+		r.client.Search.WithTimeout(suggestTimeout),
+	)
+	if err != nil {
+		return nil, requestError("error executing suggestion", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, responseError("error executing suggestion", res)
+	}
+
+	var result struct {
+		Hits struct {
+			Hits []struct {
+				Score  float64           `json:"_score"`
+				Source map[string]string `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+		Aggregations struct {
+			Skills struct {
+				Buckets []struct {
+					Key      string `json:"key"`
+					DocCount int64  `json:"doc_count"`
+				} `json:"buckets"`
+			} `json:"skills"`
+		} `json:"aggregations"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error parsing response body: %w", err)
+	}
+
+	suggestions := []models.Suggestion{}
+	if field == models.SuggestSkill {
+		for _, bucket := range result.Aggregations.Skills.Buckets {
+			suggestions = append(suggestions, models.Suggestion{
+				Text:  bucket.Key,
+				Score: float64(bucket.DocCount),
+			})
+		}
+		return suggestions, nil
+	}
+
+	for _, hit := range result.Hits.Hits {
+		text := hit.Source[string(field)]
+		if text == "" {
+			continue
+		}
+		suggestions = append(suggestions, models.Suggestion{
+			Text:  text,
+			Score: hit.Score,
+		})
+	}
+
+	return suggestions, nil
+}
+
+// caseInsensitivePrefixRegexp builds a Lucene regular expression matching
+// values starting with prefix in any letter case, e.g. "Go" -> "[gG][oO].*".
+// Terms aggregation include patterns have no case-insensitive flag.
+func caseInsensitivePrefixRegexp(prefix string) string {
+	var b strings.Builder
+	for _, r := range prefix {
+		lower, upper := unicode.ToLower(r), unicode.ToUpper(r)
+		switch {
+		case lower != upper:
+			b.WriteString("[" + string(lower) + string(upper) + "]")
+		case strings.ContainsRune(`.?+*|{}[]()"\#@&<>~`, r):
+			b.WriteString(`\` + string(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteString(".*")
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"fmt"
+	"job-search-service/internal/errs"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrVersionConflict = &errs.Error{
+		Kind:   errs.Conflict,
+		Reason: "VERSION_CONFLICT",
+		Msg:    "job was modified by another request",
+	}
+	ErrInvalidVersion = &errs.Error{
+		Kind:   errs.InvalidArgument,
+		Reason: "INVALID_VERSION",
+		Field:  "version",
+		Msg:    "invalid job version",
+	}
+)
+
+// A job version identifies the last write to the document: the generation of
+// the physical index holding it and the Elasticsearch _primary_term and
+// _seq_no of the write. Sequence numbers start over in the index a migration
+// creates, so the generation keeps a version read before a migration from
+// matching another write in the new index. Clients treat it as opaque.
+func formatVersion(generation, seqNo, primaryTerm int64) string {
+	return fmt.Sprintf("%d.%d.%d", generation, primaryTerm, seqNo)
+}
+
+func parseVersion(version string) (generation, seqNo, primaryTerm int, err error) {
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+	}
+
+	generation, err = strconv.Atoi(parts[0])
+	if err != nil || generation < 0 {
+		return 0, 0, 0, fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+	}
+
+	primaryTerm, err = strconv.Atoi(parts[1])
+	if err != nil || primaryTerm < 1 {
+		return 0, 0, 0, fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+	}
+
+	seqNo, err = strconv.Atoi(parts[2])
+	if err != nil || seqNo < 0 {
+		return 0, 0, 0, fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+	}
+
+	return generation, seqNo, primaryTerm, nil
+}
+
+// indexGeneration returns the mapping version of a physical index named
+// <alias>_v<N> by migration.Migrator, or 0 for a plain index created before
+// indices were versioned.
+func indexGeneration(index string) int64 {
+	i := strings.LastIndex(index, "_v")
+	if i < 0 {
+		return 0
+	}
+	generation, err := strconv.ParseInt(index[i+2:], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return generation
+}
+
+type writeResponse struct {
+	Index       string `json:"_index"`
+	SeqNo       int64  `json:"_seq_no"`
+	PrimaryTerm int64  `json:"_primary_term"`
+}
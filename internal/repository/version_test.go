@@ -0,0 +1,22 @@
+package repository
+
+import "testing"
+
+func TestIndexGeneration(t *testing.T) {
+	tests := []struct {
+		index string
+		want  int64
+	}{
+		{index: "jobs_v1", want: 1},
+		{index: "jobs_v12", want: 12},
+		{index: "my_vacancies_v3", want: 3},
+		{index: "jobs", want: 0},
+		{index: "jobs_vnext", want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := indexGeneration(tt.index); got != tt.want {
+			t.Errorf("indexGeneration(%q) = %d, want %d", tt.index, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"job-search-service/internal/models"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBulkBatchSize     = 500
+	defaultBulkFlushInterval = time.Second
+)
+
+// BulkOptions controls how bulk imports are batched.
+type BulkOptions struct {
+	// BatchSize is the number of jobs written per request.
+	BatchSize int
+	// FlushInterval is the longest a job waits for its batch to fill up.
+	FlushInterval time.Duration
+}
+
+// BulkResult is the outcome of one job of a bulk import: the ID it was
+// created with, or the error it was rejected with.
+type BulkResult struct {
+	ID  string
+	Err error
+}
+
+// BulkCreate imports jobs in batches. Jobs are validated as they are added
+// and written once a batch is full or FlushInterval after the first job of
+// the batch; the index is refreshed only once, by Close.
+type BulkCreate struct {
+	service *JobService
+	ctx     context.Context
+
+	mu      sync.Mutex
+	pending []*models.Job
+	// slots holds the index in results of each pending job.
+	slots   []int
+	results []BulkResult
+	created int
+	timer   *time.Timer
+}
+
+// BulkCreate starts a bulk import. All writes use ctx, including those
+// started by the flush timer.
+func (s *JobService) BulkCreate(ctx context.Context) *BulkCreate {
+	return &BulkCreate{service: s, ctx: ctx}
+}
+
+// Add queues a job, validating and normalising it like CreateJob.
+func (b *BulkCreate) Add(input *models.Job) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	slot := len(b.results)
+	b.results = append(b.results, BulkResult{})
+
+	job, err := b.service.newJob(input)
+	if err != nil {
+		b.results[slot].Err = fmt.Errorf("failed to create job: %w", err)
+		return
+	}
+	b.pending = append(b.pending, job)
+	b.slots = append(b.slots, slot)
+
+	if len(b.pending) >= b.service.bulk.BatchSize {
+		b.flush()
+		return
+	}
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.service.bulk.FlushInterval, func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			b.flush()
+		})
+	}
+}
+
+// Fail records a job that was rejected before it could be added.
+func (b *BulkCreate) Fail(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.results = append(b.results, BulkResult{Err: err})
+}
+
+// flush writes the pending jobs. A failed batch fails each of its jobs, so
+// the import goes on with the next batch. b.mu must be held.
+func (b *BulkCreate) flush() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if len(b.pending) == 0 {
+		return
+	}
+
+	failures, err := b.service.repo.CreateBatch(b.ctx, b.pending)
+	for i, job := range b.pending {
+		result := &b.results[b.slots[i]]
+		switch {
+		case err != nil:
+			result.Err = fmt.Errorf("failed to create job: %w", err)
+		case failures[i] != nil:
+			result.Err = fmt.Errorf("failed to create job: %w", failures[i])
+		default:
+			result.ID = job.ID
+			b.created++
+		}
+	}
+	b.pending, b.slots = nil, nil
+}
+
+// Close writes the remaining jobs, makes the created ones searchable and
+// returns one result per job in the order they were added or failed. The
+// results are complete even when the refresh fails: the jobs are stored
+// under their IDs and become searchable with the next periodic refresh, so
+// the error is only worth reporting, not retrying the import over.
+func (b *BulkCreate) Close() ([]BulkResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.flush()
+	if b.created > 0 {
+		if err := b.service.repo.Refresh(b.ctx); err != nil {
+			return b.results, fmt.Errorf("failed to refresh jobs: %w", err)
+		}
+	}
+	return b.results, nil
+}
+
+// Abort drops the jobs not written yet, for imports whose stream broke
+// before the client could be told which jobs were created. Batches already
+// written stay.
+func (b *BulkCreate) Abort() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.pending, b.slots = nil, nil
+}
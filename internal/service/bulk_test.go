@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"job-search-service/internal/models"
+	"job-search-service/internal/repository"
+	"testing"
+	"time"
+)
+
+// refreshFailingStore fails every refresh after writing.
+type refreshFailingStore struct {
+	repository.JobStore
+}
+
+func (refreshFailingStore) Refresh(ctx context.Context) error {
+	return errors.New("refresh timed out")
+}
+
+// bulkOptions makes every second job complete a batch and never flushes on
+// time.
+var bulkOptions = BulkOptions{BatchSize: 2, FlushInterval: time.Hour}
+
+func TestBulkCreateKeepsResultsWhenRefreshFails(t *testing.T) {
+	s, store := newTestService(t, func(store repository.JobStore) repository.JobStore {
+		return refreshFailingStore{store}
+	}, bulkOptions)
+	ctx := context.Background()
+
+	bulk := s.BulkCreate(ctx)
+	bulk.Add(&models.Job{Title: "Backend Engineer", Company: "Acme"})
+	bulk.Add(&models.Job{Title: "", Company: "Acme"})
+	bulk.Add(&models.Job{Title: "Data Engineer", Company: "Acme"})
+
+	results, err := bulk.Close()
+	if err == nil {
+		t.Error("Close did not report the failed refresh")
+	}
+	if len(results) != 3 {
+		t.Fatalf("Close returned %d results, want 3", len(results))
+	}
+	for _, i := range []int{0, 2} {
+		if results[i].ID == "" || results[i].Err != nil {
+			t.Errorf("result %d = %+v, want the ID of a created job", i, results[i])
+			continue
+		}
+		if _, err := store.GetByID(ctx, results[i].ID); err != nil {
+			t.Errorf("job %d was not stored: %v", i, err)
+		}
+	}
+	if results[1].Err == nil {
+		t.Error("job without a title was not rejected")
+	}
+}
+
+func TestBulkCreateAbortDropsPendingJobs(t *testing.T) {
+	s, store := newTestService(t, nil, bulkOptions)
+	ctx := context.Background()
+
+	bulk := s.BulkCreate(ctx)
+	for _, title := range []string{"Backend Engineer", "Data Engineer", "Platform Engineer"} {
+		bulk.Add(&models.Job{Title: title, Company: "Acme"})
+	}
+	bulk.Abort()
+
+	// The first batch of two was written before the stream broke.
+	result, err := store.Search(ctx, models.SearchParams{PageSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 2 {
+		t.Errorf("store holds %d jobs, want the 2 of the written batch", result.Total)
+	}
+}
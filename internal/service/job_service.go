@@ -2,34 +2,63 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"job-search-service/internal/compensation"
+	"job-search-service/internal/errs"
+	"job-search-service/internal/geo"
 	"job-search-service/internal/models"
+	"job-search-service/internal/querylang"
+	"job-search-service/internal/ranking"
 	"job-search-service/internal/repository"
+	"job-search-service/internal/taxonomy"
+	"job-search-service/internal/validation"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
 type JobService struct {
-	repo *repository.JobRepository
+	repo         repository.JobStore
+	compensation *compensation.Converter
+	skills       *taxonomy.Taxonomy
+	limits       querylang.Limits
+	ranking      ranking.Config
+	bulk         BulkOptions
 }
 
-func NewJobService(repo *repository.JobRepository) *JobService {
+// NewJobService uses defaultMaxQueryLength and defaultMaxClauses for limits
+// that are not set, and the bulk defaults for unset bulk options.
+func NewJobService(repo repository.JobStore, converter *compensation.Converter, skills *taxonomy.Taxonomy, limits querylang.Limits, profiles ranking.Config, bulk BulkOptions) *JobService {
+	if limits.MaxLength <= 0 {
+		limits.MaxLength = defaultMaxQueryLength
+	}
+	if limits.MaxTerms <= 0 {
+		limits.MaxTerms = defaultMaxClauses
+	}
+	if bulk.BatchSize <= 0 {
+		bulk.BatchSize = defaultBulkBatchSize
+	}
+	if bulk.FlushInterval <= 0 {
+		bulk.FlushInterval = defaultBulkFlushInterval
+	}
+
 	return &JobService{
-		repo: repo,
+		repo:         repo,
+		compensation: converter,
+		skills:       skills,
+		limits:       limits,
+		ranking:      profiles,
+		bulk:         bulk,
 	}
 }
 
-func (s *JobService) CreateJob(ctx context.Context, title, description, company, location string, skills []string, salary float64) (string, error) {
-	job := &models.Job{
-		ID:          uuid.New().String(),
-		Title:       title,
-		Description: description,
-		Company:     company,
-		Location:    location,
-		Skills:      skills,
-		Salary:      salary,
-		CreatedAt:   time.Now(),
+func (s *JobService) CreateJob(ctx context.Context, input *models.Job) (string, error) {
+	job, err := s.newJob(input)
+	if err != nil {
+		return "", fmt.Errorf("failed to create job: %w", err)
 	}
 
 	if err := s.repo.Create(ctx, job); err != nil {
@@ -39,13 +68,215 @@ func (s *JobService) CreateJob(ctx context.Context, title, description, company,
 	return job.ID, nil
 }
 
-func (s *JobService) SearchJobs(ctx context.Context, query, location string, skills []string) ([]*models.Job, error) {
-	jobs, err := s.repo.Search(ctx, query, location, skills)
+// newJob validates input and returns it as a new job with an ID, canonical
+// skills, coordinates and a normalised salary.
+func (s *JobService) newJob(input *models.Job) (*models.Job, error) {
+	now := time.Now()
+	job := &models.Job{
+		ID:             uuid.New().String(),
+		Title:          input.Title,
+		Description:    input.Description,
+		Company:        input.Company,
+		Location:       input.Location,
+		Skills:         input.Skills,
+		Salary:         input.Salary,
+		SalaryMin:      input.SalaryMin,
+		SalaryMax:      input.SalaryMax,
+		Currency:       input.Currency,
+		PayPeriod:      input.PayPeriod,
+		EmploymentType: input.EmploymentType,
+		Seniority:      input.Seniority,
+		Geo:            input.Geo,
+		WorkMode:       input.WorkMode,
+		Remote:         input.Remote,
+		Featured:       input.Featured,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	// Skills are validated as given, so that duplicates and empty entries
+	// are reported rather than dropped by Canonicalize.
+	if err := validation.Job(job); err != nil {
+		return nil, err
+	}
+	job.Skills = s.skills.Canonicalize(job.Skills)
+	geocode(job)
+	inferWorkMode(job)
+	if err := s.compensation.Normalize(job); err != nil {
+		return nil, compensationError(err, "currency")
+	}
+
+	return job, nil
+}
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+
+	defaultFacetSize              = 10
+	maxFacetSize                  = 100
+	defaultSalaryFacetInterval    = 10000
+	defaultCreatedAtFacetInterval = "month"
+
+	defaultHighlightPreTag       = "<em>"
+	defaultHighlightPostTag      = "</em>"
+	defaultHighlightFragmentSize = 150
+	defaultHighlightFragments    = 3
+
+	defaultMaxQueryLength = 256
+	defaultMaxClauses     = 32
+)
+
+var (
+	ErrUnknownLocation = &errs.Error{
+		Kind:   errs.InvalidArgument,
+		Reason: "UNKNOWN_LOCATION",
+		Field:  "geo_distance.origin_location",
+		Msg:    "unknown location",
+	}
+	// ErrQueryLimit is returned for searches exceeding the configured size
+	// limits. Oversized query strings fail to parse like other invalid
+	// queries.
+	ErrQueryLimit = &errs.Error{
+		Kind:   errs.InvalidArgument,
+		Reason: "QUERY_LIMIT_EXCEEDED",
+		Msg:    "query exceeds limits",
+	}
+	ErrInvalidUpdateMask = &errs.Error{
+		Kind:   errs.InvalidArgument,
+		Reason: "INVALID_UPDATE_MASK",
+		Field:  "update_mask",
+		Msg:    "invalid update mask",
+	}
+)
+
+func (s *JobService) SearchJobs(ctx context.Context, params models.SearchParams) (*models.SearchResult, error) {
+	if err := validation.Search(&params); err != nil {
+		return nil, fmt.Errorf("failed to search jobs: %w", err)
+	}
+	if near := params.Near; near != nil && near.Origin == nil {
+		point, ok := geo.Lookup(near.OriginLocation)
+		if !ok {
+			return nil, fmt.Errorf("failed to search jobs: %w: %q", ErrUnknownLocation, near.OriginLocation)
+		}
+		near.Origin = &point
+	}
+
+	// Salary bounds are compared against the normalised annual range.
+	for _, bound := range []**float64{&params.MinSalary, &params.MaxSalary} {
+		if *bound == nil {
+			continue
+		}
+		annual, err := s.compensation.Annual(**bound, params.SalaryCurrency, params.SalaryPeriod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search jobs: %w", compensationError(err, "salary_currency"))
+		}
+		*bound = &annual
+	}
+
+	if _, err := querylang.Parse(params.Query, s.limits); err != nil {
+		return nil, fmt.Errorf("failed to search jobs: invalid query: %w", errs.Wrap(errs.InvalidArgument, "INVALID_QUERY", "query", err))
+	}
+	if len(params.Skills) > s.limits.MaxTerms {
+		return nil, fmt.Errorf("failed to search jobs: %w: more than %d skills", ErrQueryLimit.WithField("skills"), s.limits.MaxTerms)
+	}
+	params.Skills = s.skills.Canonicalize(params.Skills)
+
+	profile, err := s.ranking.Profile(params.RankingProfile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search jobs: %w", errs.Wrap(errs.InvalidArgument, "UNKNOWN_RANKING_PROFILE", "ranking_profile", err))
+	}
+	params.Ranking = profile
+
+	if params.PageSize <= 0 {
+		params.PageSize = defaultPageSize
+	}
+	if params.PageSize > maxPageSize {
+		params.PageSize = maxPageSize
+	}
+
+	for i := range params.Facets {
+		facet := &params.Facets[i]
+		if facet.Size <= 0 {
+			facet.Size = defaultFacetSize
+		}
+		if facet.Size > maxFacetSize {
+			facet.Size = maxFacetSize
+		}
+		if facet.Interval <= 0 {
+			facet.Interval = defaultSalaryFacetInterval
+		}
+		if facet.CalendarInterval == "" {
+			facet.CalendarInterval = defaultCreatedAtFacetInterval
+		}
+	}
+
+	if highlight := params.Highlight; highlight != nil {
+		if highlight.PreTag == "" {
+			highlight.PreTag = defaultHighlightPreTag
+		}
+		if highlight.PostTag == "" {
+			highlight.PostTag = defaultHighlightPostTag
+		}
+		if highlight.FragmentSize <= 0 {
+			highlight.FragmentSize = defaultHighlightFragmentSize
+		}
+		if highlight.NumberOfFragments <= 0 {
+			highlight.NumberOfFragments = defaultHighlightFragments
+		}
+	}
+
+	result, err := s.repo.Search(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search jobs: %w", err)
 	}
 
-	return jobs, nil
+	if params.AutoCorrect && result.SuggestedQuery != "" {
+		params.Query = result.SuggestedQuery
+		corrected, err := s.repo.Search(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search jobs: %w", err)
+		}
+		corrected.SuggestedQuery = result.SuggestedQuery
+		corrected.QueryCorrected = true
+		return corrected, nil
+	}
+
+	return result, nil
+}
+
+const (
+	defaultSuggestionSize = 5
+	maxSuggestionSize     = 20
+	// suggestBudget is the total time a suggestion request may take,
+	// including the round trip to Elasticsearch.
+	suggestBudget = 250 * time.Millisecond
+)
+
+func (s *JobService) SuggestJobs(ctx context.Context, prefix string, field models.SuggestField, size int) ([]models.Suggestion, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return []models.Suggestion{}, nil
+	}
+	if utf8.RuneCountInString(prefix) > s.limits.MaxLength {
+		return nil, fmt.Errorf("failed to suggest jobs: %w: prefix is longer than %d characters", ErrQueryLimit.WithField("prefix"), s.limits.MaxLength)
+	}
+
+	if size <= 0 {
+		size = defaultSuggestionSize
+	}
+	if size > maxSuggestionSize {
+		size = maxSuggestionSize
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, suggestBudget)
+	defer cancel()
+
+	suggestions, err := s.repo.Suggest(ctx, prefix, field, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest jobs: %w", err)
+	}
+
+	return suggestions, nil
 }
 
 func (s *JobService) GetJob(ctx context.Context, id string) (*models.Job, error) {
@@ -57,10 +288,172 @@ func (s *JobService) GetJob(ctx context.Context, id string) (*models.Job, error)
 	return job, nil
 }
 
-func (s *JobService) DeleteJob(ctx context.Context, id string) error {
-	if err := s.repo.Delete(ctx, id); err != nil {
+// UpdateJob replaces the editable fields of a job. An empty version still
+// guards against concurrent writers by checking against the version read here.
+func (s *JobService) UpdateJob(ctx context.Context, id, version string, input *models.Job) (*models.Job, error) {
+	job, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update job: %w", err)
+	}
+
+	if err := applyFields(job, input, editableFields); err != nil {
+		return nil, fmt.Errorf("failed to update job: %w", err)
+	}
+	if err := validation.Job(job); err != nil {
+		return nil, fmt.Errorf("failed to update job: %w", err)
+	}
+	if err := s.compensation.Normalize(job); err != nil {
+		return nil, fmt.Errorf("failed to update job: %w", compensationError(err, "currency"))
+	}
+	job.Skills = s.skills.Canonicalize(job.Skills)
+	job.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, job, expectedVersion(version, job)); err != nil {
+		return nil, fmt.Errorf("failed to update job: %w", err)
+	}
+
+	return job, nil
+}
+
+// PatchJob copies only the fields named in paths from patch onto the stored
+// job. Paths use the proto field names of the Job message.
+func (s *JobService) PatchJob(ctx context.Context, id, version string, patch *models.Job, paths []string) (*models.Job, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("failed to patch job: %w: update mask is empty", ErrInvalidUpdateMask)
+	}
+
+	job, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to patch job: %w", err)
+	}
+
+	if err := applyFields(job, patch, paths); err != nil {
+		return nil, fmt.Errorf("failed to patch job: %w", err)
+	}
+	if err := validation.Job(job); err != nil {
+		return nil, fmt.Errorf("failed to patch job: %w", err)
+	}
+	if err := s.compensation.Normalize(job); err != nil {
+		return nil, fmt.Errorf("failed to patch job: %w", compensationError(err, "currency"))
+	}
+	job.Skills = s.skills.Canonicalize(job.Skills)
+	job.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, job, expectedVersion(version, job)); err != nil {
+		return nil, fmt.Errorf("failed to patch job: %w", err)
+	}
+
+	return job, nil
+}
+
+// editableFields lists the update mask paths a client may change, using the
+// proto field names of the Job message.
+var editableFields = []string{
+	"title", "description", "company", "location", "skills", "salary",
+	"salary_min", "salary_max", "currency", "pay_period", "employment_type", "seniority",
+	"geo", "work_mode", "remote_policy", "featured",
+}
+
+func applyFields(job, input *models.Job, paths []string) error {
+	locationChanged, geoSet := false, false
+
+	for _, path := range paths {
+		switch path {
+		case "title":
+			job.Title = input.Title
+		case "description":
+			job.Description = input.Description
+		case "company":
+			job.Company = input.Company
+		case "location":
+			locationChanged = job.Location != input.Location
+			job.Location = input.Location
+		case "skills":
+			job.Skills = input.Skills
+		case "salary":
+			job.Salary = input.Salary
+		case "salary_min":
+			job.SalaryMin = input.SalaryMin
+		case "salary_max":
+			job.SalaryMax = input.SalaryMax
+		case "currency":
+			job.Currency = input.Currency
+		case "pay_period":
+			job.PayPeriod = input.PayPeriod
+		case "employment_type":
+			job.EmploymentType = input.EmploymentType
+		case "seniority":
+			job.Seniority = input.Seniority
+		case "geo":
+			job.Geo = input.Geo
+			geoSet = true
+		case "work_mode":
+			job.WorkMode = input.WorkMode
+		case "remote_policy":
+			job.Remote = input.Remote
+		case "featured":
+			job.Featured = input.Featured
+		default:
+			return fmt.Errorf("%w: unsupported path %q", ErrInvalidUpdateMask, path)
+		}
+	}
+
+	// Coordinates derived from the old location no longer apply.
+	if locationChanged && !geoSet {
+		job.Geo = nil
+	}
+	geocode(job)
+	inferWorkMode(job)
+
+	return nil
+}
+
+// compensationError classifies errors of the compensation converter, whose
+// currency comes from currencyField of the request.
+func compensationError(err error, currencyField string) error {
+	switch {
+	case errors.Is(err, compensation.ErrUnknownCurrency):
+		return errs.Wrap(errs.InvalidArgument, "UNKNOWN_CURRENCY", currencyField, err)
+	case errors.Is(err, compensation.ErrInvalidSalaryRange):
+		return errs.Wrap(errs.InvalidArgument, "INVALID_SALARY_RANGE", "salary_min", err)
+	default:
+		return err
+	}
+}
+
+// inferWorkMode marks jobs whose location is just "Remote" as remote, since
+// that is how remote postings were entered before work_mode existed.
+func inferWorkMode(job *models.Job) {
+	if job.WorkMode == "" && strings.EqualFold(strings.TrimSpace(job.Location), "remote") {
+		job.WorkMode = models.WorkModeRemote
+	}
+	if job.WorkMode != models.WorkModeRemote {
+		job.Remote = nil
+	}
+}
+
+// geocode fills in the coordinates of a job without explicit ones from the
+// offline gazetteer. Unknown locations are left without coordinates.
+func geocode(job *models.Job) {
+	if job.Geo != nil || job.Location == "" {
+		return
+	}
+	if point, ok := geo.Lookup(job.Location); ok {
+		job.Geo = &point
+	}
+}
+
+func (s *JobService) DeleteJob(ctx context.Context, id, version string) error {
+	if err := s.repo.Delete(ctx, id, version); err != nil {
 		return fmt.Errorf("failed to delete job: %w", err)
 	}
 
 	return nil
 }
+
+func expectedVersion(requested string, current *models.Job) string {
+	if requested != "" {
+		return requested
+	}
+	return current.Version
+}
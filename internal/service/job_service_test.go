@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"job-search-service/internal/compensation"
+	"job-search-service/internal/errs"
+	"job-search-service/internal/models"
+	"job-search-service/internal/querylang"
+	"job-search-service/internal/ranking"
+	"job-search-service/internal/repository"
+	"job-search-service/internal/taxonomy"
+	"reflect"
+	"testing"
+)
+
+// newTestService returns a service over a memory store, optionally wrapped
+// by wrap, and the store it uses.
+func newTestService(t *testing.T, wrap func(repository.JobStore) repository.JobStore, bulk BulkOptions) (*JobService, repository.JobStore) {
+	t.Helper()
+
+	skills, err := taxonomy.Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	converter, err := compensation.NewConverter("EUR", map[string]float64{"USD": 0.9}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var store repository.JobStore = repository.NewMemoryJobRepository(skills)
+	if wrap != nil {
+		store = wrap(store)
+	}
+	return NewJobService(store, converter, skills, querylang.Limits{}, ranking.Config{}, bulk), store
+}
+
+// createRemoteJob stores a featured remote job with a salary range and a
+// remote policy, so that tests can check these are cleared again.
+func createRemoteJob(t *testing.T, s *JobService) *models.Job {
+	t.Helper()
+
+	id, err := s.CreateJob(context.Background(), &models.Job{
+		Title:     "Backend Engineer",
+		Company:   "Acme",
+		Location:  "Remote",
+		Skills:    []string{"Go"},
+		SalaryMin: 60000,
+		SalaryMax: 80000,
+		Currency:  "USD",
+		WorkMode:  models.WorkModeRemote,
+		Remote:    &models.RemotePolicy{AllowedCountries: []string{"DE"}},
+		Featured:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	job, err := s.GetJob(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+func TestUpdateJobReplacesFields(t *testing.T) {
+	s, _ := newTestService(t, nil, BulkOptions{})
+	ctx := context.Background()
+	job := createRemoteJob(t, s)
+
+	updated, err := s.UpdateJob(ctx, job.ID, job.Version, &models.Job{
+		Title:    "Platform Engineer",
+		Company:  "Acme",
+		Location: "Berlin",
+		WorkMode: models.WorkModeOnsite,
+	})
+	if err != nil {
+		t.Fatalf("UpdateJob returned error: %v", err)
+	}
+
+	stored, err := s.GetJob(ctx, job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, got := range []*models.Job{updated, stored} {
+		if got.Title != "Platform Engineer" || got.Featured || got.Remote != nil || got.Currency != "" {
+			t.Errorf("job = %+v, want the old featured flag, remote policy and currency cleared", got)
+		}
+		if got.AnnualSalaryMin != 0 || got.AnnualSalaryMax != 0 {
+			t.Errorf("annual salary = %v..%v, want none", got.AnnualSalaryMin, got.AnnualSalaryMax)
+		}
+		if got.Geo == nil || got.Geo.Lat != 52.52 {
+			t.Errorf("Geo = %v, want the coordinates of Berlin", got.Geo)
+		}
+		if !got.CreatedAt.Equal(job.CreatedAt) {
+			t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, job.CreatedAt)
+		}
+	}
+	if stored.Version != updated.Version || stored.Version == job.Version {
+		t.Errorf("stored version %s, returned %s, previous %s", stored.Version, updated.Version, job.Version)
+	}
+}
+
+func TestPatchJob(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup, if set, is patched onto the job before patch.
+		setup *models.Job
+		patch models.Job
+		paths []string
+		check func(t *testing.T, before, after *models.Job)
+	}{
+		{
+			name:  "unset featured",
+			patch: models.Job{Title: "ignored"},
+			paths: []string{"featured"},
+			check: func(t *testing.T, before, after *models.Job) {
+				if after.Featured {
+					t.Error("Featured is still set")
+				}
+				if after.Title != before.Title {
+					t.Errorf("Title = %q, want %q", after.Title, before.Title)
+				}
+			},
+		},
+		{
+			name:  "leave remote",
+			patch: models.Job{Location: "Munich", WorkMode: models.WorkModeHybrid},
+			paths: []string{"location", "work_mode"},
+			check: func(t *testing.T, before, after *models.Job) {
+				if after.Remote != nil {
+					t.Errorf("Remote = %+v, want it dropped", after.Remote)
+				}
+				if after.Geo == nil || after.Geo.Lat != 48.1351 {
+					t.Errorf("Geo = %v, want the coordinates of Munich", after.Geo)
+				}
+			},
+		},
+		{
+			name:  "move to an unknown place",
+			setup: &models.Job{Location: "Berlin"},
+			patch: models.Job{Location: "Springfield"},
+			paths: []string{"location"},
+			check: func(t *testing.T, before, after *models.Job) {
+				if after.Geo != nil {
+					t.Errorf("Geo = %v, want the old coordinates dropped", after.Geo)
+				}
+			},
+		},
+		{
+			name:  "change the pay period",
+			patch: models.Job{PayPeriod: models.PayPeriodMonthly},
+			paths: []string{"pay_period"},
+			check: func(t *testing.T, before, after *models.Job) {
+				if after.AnnualSalaryMax != before.AnnualSalaryMax*12 {
+					t.Errorf("AnnualSalaryMax = %v, want %v", after.AnnualSalaryMax, before.AnnualSalaryMax*12)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestService(t, nil, BulkOptions{})
+			ctx := context.Background()
+			before := createRemoteJob(t, s)
+			if tt.setup != nil {
+				var err error
+				before, err = s.PatchJob(ctx, before.ID, "", tt.setup, tt.paths)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, err := s.PatchJob(ctx, before.ID, before.Version, &tt.patch, tt.paths); err != nil {
+				t.Fatalf("PatchJob returned error: %v", err)
+			}
+			after, err := s.GetJob(ctx, before.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, before, after)
+		})
+	}
+}
+
+func TestUpdateErrors(t *testing.T) {
+	s, _ := newTestService(t, nil, BulkOptions{})
+	ctx := context.Background()
+	job := createRemoteJob(t, s)
+	if _, err := s.PatchJob(ctx, job.ID, "", &models.Job{Title: "Staff Engineer"}, []string{"title"}); err != nil {
+		t.Fatal(err)
+	}
+	stale := job.Version
+
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{
+			name: "update with a stale version",
+			call: func() error {
+				_, err := s.UpdateJob(ctx, job.ID, stale, &models.Job{Title: "Go Engineer", Company: "Acme"})
+				return err
+			},
+			want: repository.ErrVersionConflict,
+		},
+		{
+			name: "patch with a stale version",
+			call: func() error {
+				_, err := s.PatchJob(ctx, job.ID, stale, &models.Job{Featured: false}, []string{"featured"})
+				return err
+			},
+			want: repository.ErrVersionConflict,
+		},
+		{
+			name: "patch without a mask",
+			call: func() error {
+				_, err := s.PatchJob(ctx, job.ID, "", &models.Job{}, nil)
+				return err
+			},
+			want: ErrInvalidUpdateMask,
+		},
+		{
+			name: "patch of a field that cannot change",
+			call: func() error {
+				_, err := s.PatchJob(ctx, job.ID, "", &models.Job{ID: "other"}, []string{"id"})
+				return err
+			},
+			want: ErrInvalidUpdateMask,
+		},
+		{
+			name: "update of a missing job",
+			call: func() error {
+				_, err := s.UpdateJob(ctx, "missing", "", &models.Job{Title: "Go Engineer", Company: "Acme"})
+				return err
+			},
+			want: repository.ErrJobNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// Updates are validated as a whole job.
+	_, err := s.PatchJob(ctx, job.ID, "", &models.Job{}, []string{"title"})
+	var domainErr *errs.Error
+	if !errors.As(err, &domainErr) || domainErr.Kind != errs.InvalidArgument {
+		t.Errorf("patch clearing the title error = %v, want an invalid argument", err)
+	}
+}
+
+func TestSkillsValidatedBeforeCanonicalizing(t *testing.T) {
+	s, _ := newTestService(t, nil, BulkOptions{})
+	ctx := context.Background()
+	job := createRemoteJob(t, s)
+	skills := []string{"Go", "go", ""}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "create",
+			call: func() error {
+				_, err := s.CreateJob(ctx, &models.Job{Title: "Go Engineer", Company: "Acme", Skills: skills})
+				return err
+			},
+		},
+		{
+			name: "update",
+			call: func() error {
+				_, err := s.UpdateJob(ctx, job.ID, "", &models.Job{Title: "Go Engineer", Company: "Acme", Skills: skills})
+				return err
+			},
+		},
+		{
+			name: "patch",
+			call: func() error {
+				_, err := s.PatchJob(ctx, job.ID, "", &models.Job{Skills: skills}, []string{"skills"})
+				return err
+			},
+		},
+	}
+	want := []errs.Violation{
+		{Field: "skills[1]", Description: `skills[1] duplicates "go"`},
+		{Field: "skills[2]", Description: "skills[2] must not be empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			var domainErr *errs.Error
+			if !errors.As(err, &domainErr) || domainErr.Kind != errs.InvalidArgument {
+				t.Fatalf("error = %v, want an invalid argument", err)
+			}
+			if !reflect.DeepEqual(domainErr.Violations, want) {
+				t.Errorf("violations = %+v, want %+v", domainErr.Violations, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package taxonomy
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Skill struct {
+	Name    string   `yaml:"name"`
+	Aliases []string `yaml:"aliases"`
+}
+
+// Taxonomy maps the different spellings of a skill ("golang", "k8s") to its
+// canonical name. It is safe for concurrent use and can be reloaded from its
+// file while the server is running.
+type Taxonomy struct {
+	path string
+
+	mu        sync.RWMutex
+	skills    []Skill
+	canonical map[string]string
+}
+
+// Load reads the taxonomy from a YAML file. An empty path gives an empty
+// taxonomy that keeps skills as they are.
+func Load(path string) (*Taxonomy, error) {
+	t := &Taxonomy{path: path}
+	if err := t.Reload(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Reload rereads the taxonomy file. On error the previous taxonomy stays in
+// use.
+func (t *Taxonomy) Reload() error {
+	skills, canonical, err := parse(t.path)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	t.skills = skills
+	t.canonical = canonical
+	t.mu.Unlock()
+
+	return nil
+}
+
+func parse(path string) ([]Skill, map[string]string, error) {
+	canonical := make(map[string]string)
+	if path == "" {
+		return nil, canonical, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading skill taxonomy: %w", err)
+	}
+
+	var file struct {
+		Skills []Skill `yaml:"skills"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, nil, fmt.Errorf("error parsing skill taxonomy: %w", err)
+	}
+
+	for i, skill := range file.Skills {
+		skill.Name = strings.TrimSpace(skill.Name)
+		if skill.Name == "" {
+			return nil, nil, fmt.Errorf("skill taxonomy entry %d has no name", i+1)
+		}
+
+		aliases := make([]string, 0, len(skill.Aliases))
+		for _, spelling := range append([]string{skill.Name}, skill.Aliases...) {
+			spelling = strings.TrimSpace(spelling)
+			if spelling == "" {
+				continue
+			}
+			// Commas and arrows have a meaning in Elasticsearch synonym rules.
+			if strings.Contains(spelling, ",") || strings.Contains(spelling, "=>") {
+				return nil, nil, fmt.Errorf("skill %q: %q must not contain ',' or '=>'", skill.Name, spelling)
+			}
+			key := normalize(spelling)
+			if other, ok := canonical[key]; ok && other != skill.Name {
+				return nil, nil, fmt.Errorf("skill taxonomy maps %q to both %q and %q", spelling, other, skill.Name)
+			}
+			canonical[key] = skill.Name
+			if spelling != skill.Name {
+				aliases = append(aliases, spelling)
+			}
+		}
+		skill.Aliases = aliases
+		file.Skills[i] = skill
+	}
+
+	return file.Skills, canonical, nil
+}
+
+// Canonical returns the canonical name of a skill, or the trimmed skill
+// itself if the taxonomy does not know it.
+func (t *Taxonomy) Canonical(skill string) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if name, ok := t.canonical[normalize(skill)]; ok {
+		return name
+	}
+	return strings.TrimSpace(skill)
+}
+
+// Canonicalize maps every skill to its canonical name and drops empty and
+// duplicate entries, keeping the original order.
+func (t *Taxonomy) Canonicalize(skills []string) []string {
+	if skills == nil {
+		return nil
+	}
+
+	result := make([]string, 0, len(skills))
+	seen := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		name := t.Canonical(skill)
+		key := normalize(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, name)
+	}
+	return result
+}
+
+// SynonymRules returns one Solr-style equivalence rule per skill with aliases,
+// keyed by rule ID, e.g. "go" -> "Go, golang, go-lang". Equivalence rather
+// than explicit mappings lets queries also match jobs indexed before a
+// spelling was added to the taxonomy.
+func (t *Taxonomy) SynonymRules() map[string]string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	rules := make(map[string]string, len(t.skills))
+	for _, skill := range t.skills {
+		if len(skill.Aliases) == 0 {
+			continue
+		}
+		aliases := append([]string(nil), skill.Aliases...)
+		sort.Strings(aliases)
+		rules[normalize(skill.Name)] = skill.Name + ", " + strings.Join(aliases, ", ")
+	}
+	return rules
+}
+
+func normalize(skill string) string {
+	return strings.ToLower(strings.Join(strings.Fields(skill), " "))
+}
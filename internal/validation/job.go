@@ -0,0 +1,42 @@
+package validation
+
+import (
+	"job-search-service/internal/geo"
+	"job-search-service/internal/models"
+)
+
+const (
+	maxTitleLength       = 200
+	maxCompanyLength     = 200
+	maxLocationLength    = 200
+	maxDescriptionLength = 20000
+	maxSkills            = 50
+	maxSkillLength       = 50
+	// maxAmount bounds salaries to catch typos such as an extra zero.
+	maxAmount = 1e9
+)
+
+var jobRules = []Rule[models.Job]{
+	required("title", func(j *models.Job) string { return j.Title }),
+	maxLength("title", maxTitleLength, func(j *models.Job) string { return j.Title }),
+	maxLength("description", maxDescriptionLength, func(j *models.Job) string { return j.Description }),
+	required("company", func(j *models.Job) string { return j.Company }),
+	maxLength("company", maxCompanyLength, func(j *models.Job) string { return j.Company }),
+	maxLength("location", maxLocationLength, func(j *models.Job) string { return j.Location }),
+	skillList("skills", maxSkills, true, func(j *models.Job) []string { return j.Skills }),
+	amount("salary", func(j *models.Job) float64 { return j.Salary }),
+	amount("salary_min", func(j *models.Job) float64 { return j.SalaryMin }),
+	amount("salary_max", func(j *models.Job) float64 { return j.SalaryMax }),
+	check("salary_min", func(j *models.Job) bool {
+		return j.SalaryMin == 0 || j.SalaryMax == 0 || j.SalaryMin <= j.SalaryMax
+	}, "must not be greater than salary_max"),
+	check("geo", func(j *models.Job) bool {
+		return j.Geo == nil || geo.Valid(*j.Geo)
+	}, "must be a valid latitude and longitude"),
+}
+
+// Job checks a job before it is written. It is applied to the complete job,
+// so updates and patches are held to the same rules as new jobs.
+func Job(job *models.Job) error {
+	return validate(job, jobRules)
+}
@@ -0,0 +1,140 @@
+package validation
+
+import (
+	"fmt"
+	"job-search-service/internal/errs"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Rule checks one field of a T. Check returns the violations it finds,
+// naming the field in each; a rule may report several, e.g. one per invalid
+// list element.
+type Rule[T any] struct {
+	Check func(value *T) []errs.Violation
+}
+
+// validate runs all rules and returns an InvalidArgument error listing every
+// violation, so that clients can fix a request in one go.
+func validate[T any](value *T, rules []Rule[T]) error {
+	var violations []errs.Violation
+	for _, rule := range rules {
+		violations = append(violations, rule.Check(value)...)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return invalid(violations)
+}
+
+// FieldError reports a single invalid field found outside of the rules, such
+// as while converting a request, in the same form as rule violations.
+func FieldError(field, format string, args ...interface{}) error {
+	return invalid(violation(field, format, args...))
+}
+
+func invalid(violations []errs.Violation) error {
+	descriptions := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		descriptions = append(descriptions, violation.Description)
+	}
+	return &errs.Error{
+		Kind:       errs.InvalidArgument,
+		Reason:     "VALIDATION_FAILED",
+		Msg:        "invalid request: " + strings.Join(descriptions, "; "),
+		Violations: violations,
+	}
+}
+
+func violation(field, format string, args ...interface{}) []errs.Violation {
+	return []errs.Violation{{
+		Field:       field,
+		Description: field + " " + fmt.Sprintf(format, args...),
+	}}
+}
+
+// check turns a condition into a rule: when ok reports false the field is
+// reported with the given description.
+func check[T any](field string, ok func(*T) bool, description string) Rule[T] {
+	return Rule[T]{Check: func(value *T) []errs.Violation {
+		if ok(value) {
+			return nil
+		}
+		return violation(field, "%s", description)
+	}}
+}
+
+func required[T any](field string, get func(*T) string) Rule[T] {
+	return check(field, func(value *T) bool {
+		return strings.TrimSpace(get(value)) != ""
+	}, "is required")
+}
+
+func maxLength[T any](field string, max int, get func(*T) string) Rule[T] {
+	return check(field, func(value *T) bool {
+		return utf8.RuneCountInString(get(value)) <= max
+	}, fmt.Sprintf("must be at most %d characters", max))
+}
+
+// amount accepts salaries from 0 to maxAmount.
+func amount[T any](field string, get func(*T) float64) Rule[T] {
+	return check(field, func(value *T) bool {
+		n := get(value)
+		return n >= 0 && n <= maxAmount
+	}, fmt.Sprintf("must be between 0 and %.0f", maxAmount))
+}
+
+// optionalAmount is amount for fields that may be unset.
+func optionalAmount[T any](field string, get func(*T) *float64) Rule[T] {
+	return amount(field, func(value *T) float64 {
+		if n := get(value); n != nil {
+			return *n
+		}
+		return 0
+	})
+}
+
+// skillList checks the number of skills and each skill's length and
+// characters. With unique set, skills differing only in case or surrounding
+// whitespace are reported as duplicates.
+func skillList[T any](field string, maxSkills int, unique bool, get func(*T) []string) Rule[T] {
+	return Rule[T]{Check: func(value *T) []errs.Violation {
+		skills := get(value)
+		if len(skills) > maxSkills {
+			return violation(field, "must have at most %d entries", maxSkills)
+		}
+
+		var violations []errs.Violation
+		seen := make(map[string]bool, len(skills))
+		for i, skill := range skills {
+			element := fmt.Sprintf("%s[%d]", field, i)
+			key := strings.ToLower(strings.TrimSpace(skill))
+			switch {
+			case key == "":
+				violations = append(violations, violation(element, "must not be empty")...)
+			case utf8.RuneCountInString(skill) > maxSkillLength:
+				violations = append(violations, violation(element, "must be at most %d characters", maxSkillLength)...)
+			case !validSkill(skill):
+				violations = append(violations, violation(element, "may only contain letters, digits, spaces and %s", skillPunctuation)...)
+			case unique && seen[key]:
+				violations = append(violations, violation(element, "duplicates %q", skill)...)
+			}
+			seen[key] = true
+		}
+		return violations
+	}}
+}
+
+// skillPunctuation is allowed in skills for names such as C++, C#, Node.js
+// and CI/CD.
+const skillPunctuation = "+#./-_"
+
+func validSkill(skill string) bool {
+	for _, r := range skill {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' && !strings.ContainsRune(skillPunctuation, r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package validation
+
+import (
+	"job-search-service/internal/geo"
+	"job-search-service/internal/models"
+)
+
+var searchRules = []Rule[models.SearchParams]{
+	maxLength("location", maxLocationLength, func(p *models.SearchParams) string { return p.Location }),
+	skillList("skills", maxSkills, false, func(p *models.SearchParams) []string { return p.Skills }),
+	check("skills_minimum_match", func(p *models.SearchParams) bool {
+		return p.SkillsMatch != models.SkillsMatchAtLeast ||
+			(p.SkillsMinimumMatch >= 1 && p.SkillsMinimumMatch <= len(p.Skills))
+	}, "must be between 1 and the number of skills"),
+	optionalAmount("min_salary", func(p *models.SearchParams) *float64 { return p.MinSalary }),
+	optionalAmount("max_salary", func(p *models.SearchParams) *float64 { return p.MaxSalary }),
+	check("min_salary", func(p *models.SearchParams) bool {
+		return p.MinSalary == nil || p.MaxSalary == nil || *p.MinSalary <= *p.MaxSalary
+	}, "must not be greater than max_salary"),
+	check("posted_after", func(p *models.SearchParams) bool {
+		return p.PostedAfter.IsZero() || p.PostedBefore.IsZero() || p.PostedAfter.Before(p.PostedBefore)
+	}, "must be before posted_before"),
+	check("geo_distance", func(p *models.SearchParams) bool {
+		return p.Near == nil || p.Near.Origin != nil || p.Near.OriginLocation != ""
+	}, "requires origin or origin_location"),
+	check("geo_distance.origin", func(p *models.SearchParams) bool {
+		return p.Near == nil || p.Near.Origin == nil || geo.Valid(*p.Near.Origin)
+	}, "must be a valid latitude and longitude"),
+	check("geo_distance.radius_km", func(p *models.SearchParams) bool {
+		return p.Near == nil || p.Near.RadiusKm >= 0
+	}, "must not be negative"),
+	check("sort", func(p *models.SearchParams) bool {
+		for _, option := range p.Sort {
+			if option.Field == models.SortByDistance && p.Near == nil {
+				return false
+			}
+		}
+		return true
+	}, "by distance requires geo_distance"),
+}
+
+// Search checks the parameters of a search before any defaults are applied.
+func Search(params *models.SearchParams) error {
+	return validate(params, searchRules)
+}
@@ -9,6 +9,8 @@ package job
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,17 +23,534 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type PayPeriod int32
+
+const (
+	// Treated as yearly.
+	PayPeriod_PAY_PERIOD_UNSPECIFIED PayPeriod = 0
+	PayPeriod_PAY_PERIOD_HOURLY      PayPeriod = 1
+	PayPeriod_PAY_PERIOD_MONTHLY     PayPeriod = 2
+	PayPeriod_PAY_PERIOD_YEARLY      PayPeriod = 3
+)
+
+// Enum value maps for PayPeriod.
+var (
+	PayPeriod_name = map[int32]string{
+		0: "PAY_PERIOD_UNSPECIFIED",
+		1: "PAY_PERIOD_HOURLY",
+		2: "PAY_PERIOD_MONTHLY",
+		3: "PAY_PERIOD_YEARLY",
+	}
+	PayPeriod_value = map[string]int32{
+		"PAY_PERIOD_UNSPECIFIED": 0,
+		"PAY_PERIOD_HOURLY":      1,
+		"PAY_PERIOD_MONTHLY":     2,
+		"PAY_PERIOD_YEARLY":      3,
+	}
+)
+
+func (x PayPeriod) Enum() *PayPeriod {
+	p := new(PayPeriod)
+	*p = x
+	return p
+}
+
+func (x PayPeriod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PayPeriod) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_job_proto_enumTypes[0].Descriptor()
+}
+
+func (PayPeriod) Type() protoreflect.EnumType {
+	return &file_proto_job_proto_enumTypes[0]
+}
+
+func (x PayPeriod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PayPeriod.Descriptor instead.
+func (PayPeriod) EnumDescriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{0}
+}
+
+type EmploymentType int32
+
+const (
+	EmploymentType_EMPLOYMENT_TYPE_UNSPECIFIED EmploymentType = 0
+	EmploymentType_EMPLOYMENT_TYPE_FULL_TIME   EmploymentType = 1
+	EmploymentType_EMPLOYMENT_TYPE_PART_TIME   EmploymentType = 2
+	EmploymentType_EMPLOYMENT_TYPE_CONTRACT    EmploymentType = 3
+	EmploymentType_EMPLOYMENT_TYPE_INTERNSHIP  EmploymentType = 4
+)
+
+// Enum value maps for EmploymentType.
+var (
+	EmploymentType_name = map[int32]string{
+		0: "EMPLOYMENT_TYPE_UNSPECIFIED",
+		1: "EMPLOYMENT_TYPE_FULL_TIME",
+		2: "EMPLOYMENT_TYPE_PART_TIME",
+		3: "EMPLOYMENT_TYPE_CONTRACT",
+		4: "EMPLOYMENT_TYPE_INTERNSHIP",
+	}
+	EmploymentType_value = map[string]int32{
+		"EMPLOYMENT_TYPE_UNSPECIFIED": 0,
+		"EMPLOYMENT_TYPE_FULL_TIME":   1,
+		"EMPLOYMENT_TYPE_PART_TIME":   2,
+		"EMPLOYMENT_TYPE_CONTRACT":    3,
+		"EMPLOYMENT_TYPE_INTERNSHIP":  4,
+	}
+)
+
+func (x EmploymentType) Enum() *EmploymentType {
+	p := new(EmploymentType)
+	*p = x
+	return p
+}
+
+func (x EmploymentType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EmploymentType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_job_proto_enumTypes[1].Descriptor()
+}
+
+func (EmploymentType) Type() protoreflect.EnumType {
+	return &file_proto_job_proto_enumTypes[1]
+}
+
+func (x EmploymentType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EmploymentType.Descriptor instead.
+func (EmploymentType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{1}
+}
+
+type Seniority int32
+
+const (
+	Seniority_SENIORITY_UNSPECIFIED Seniority = 0
+	Seniority_SENIORITY_JUNIOR      Seniority = 1
+	Seniority_SENIORITY_MID         Seniority = 2
+	Seniority_SENIORITY_SENIOR      Seniority = 3
+	Seniority_SENIORITY_LEAD        Seniority = 4
+	Seniority_SENIORITY_PRINCIPAL   Seniority = 5
+)
+
+// Enum value maps for Seniority.
+var (
+	Seniority_name = map[int32]string{
+		0: "SENIORITY_UNSPECIFIED",
+		1: "SENIORITY_JUNIOR",
+		2: "SENIORITY_MID",
+		3: "SENIORITY_SENIOR",
+		4: "SENIORITY_LEAD",
+		5: "SENIORITY_PRINCIPAL",
+	}
+	Seniority_value = map[string]int32{
+		"SENIORITY_UNSPECIFIED": 0,
+		"SENIORITY_JUNIOR":      1,
+		"SENIORITY_MID":         2,
+		"SENIORITY_SENIOR":      3,
+		"SENIORITY_LEAD":        4,
+		"SENIORITY_PRINCIPAL":   5,
+	}
+)
+
+func (x Seniority) Enum() *Seniority {
+	p := new(Seniority)
+	*p = x
+	return p
+}
+
+func (x Seniority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Seniority) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_job_proto_enumTypes[2].Descriptor()
+}
+
+func (Seniority) Type() protoreflect.EnumType {
+	return &file_proto_job_proto_enumTypes[2]
+}
+
+func (x Seniority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Seniority.Descriptor instead.
+func (Seniority) EnumDescriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{2}
+}
+
+type WorkMode int32
+
+const (
+	WorkMode_WORK_MODE_UNSPECIFIED WorkMode = 0
+	WorkMode_WORK_MODE_ONSITE      WorkMode = 1
+	WorkMode_WORK_MODE_HYBRID      WorkMode = 2
+	WorkMode_WORK_MODE_REMOTE      WorkMode = 3
+)
+
+// Enum value maps for WorkMode.
+var (
+	WorkMode_name = map[int32]string{
+		0: "WORK_MODE_UNSPECIFIED",
+		1: "WORK_MODE_ONSITE",
+		2: "WORK_MODE_HYBRID",
+		3: "WORK_MODE_REMOTE",
+	}
+	WorkMode_value = map[string]int32{
+		"WORK_MODE_UNSPECIFIED": 0,
+		"WORK_MODE_ONSITE":      1,
+		"WORK_MODE_HYBRID":      2,
+		"WORK_MODE_REMOTE":      3,
+	}
+)
+
+func (x WorkMode) Enum() *WorkMode {
+	p := new(WorkMode)
+	*p = x
+	return p
+}
+
+func (x WorkMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_job_proto_enumTypes[3].Descriptor()
+}
+
+func (WorkMode) Type() protoreflect.EnumType {
+	return &file_proto_job_proto_enumTypes[3]
+}
+
+func (x WorkMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkMode.Descriptor instead.
+func (WorkMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{3}
+}
+
+type SkillsMatchMode int32
+
+const (
+	SkillsMatchMode_SKILLS_MATCH_MODE_ANY        SkillsMatchMode = 0
+	SkillsMatchMode_SKILLS_MATCH_MODE_ALL        SkillsMatchMode = 1
+	SkillsMatchMode_SKILLS_MATCH_MODE_AT_LEAST_N SkillsMatchMode = 2
+)
+
+// Enum value maps for SkillsMatchMode.
+var (
+	SkillsMatchMode_name = map[int32]string{
+		0: "SKILLS_MATCH_MODE_ANY",
+		1: "SKILLS_MATCH_MODE_ALL",
+		2: "SKILLS_MATCH_MODE_AT_LEAST_N",
+	}
+	SkillsMatchMode_value = map[string]int32{
+		"SKILLS_MATCH_MODE_ANY":        0,
+		"SKILLS_MATCH_MODE_ALL":        1,
+		"SKILLS_MATCH_MODE_AT_LEAST_N": 2,
+	}
+)
+
+func (x SkillsMatchMode) Enum() *SkillsMatchMode {
+	p := new(SkillsMatchMode)
+	*p = x
+	return p
+}
+
+func (x SkillsMatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SkillsMatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_job_proto_enumTypes[4].Descriptor()
+}
+
+func (SkillsMatchMode) Type() protoreflect.EnumType {
+	return &file_proto_job_proto_enumTypes[4]
+}
+
+func (x SkillsMatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SkillsMatchMode.Descriptor instead.
+func (SkillsMatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{4}
+}
+
+type SortField int32
+
+const (
+	SortField_SORT_FIELD_RELEVANCE  SortField = 0
+	SortField_SORT_FIELD_CREATED_AT SortField = 1
+	SortField_SORT_FIELD_SALARY     SortField = 2
+	SortField_SORT_FIELD_TITLE      SortField = 3
+	SortField_SORT_FIELD_COMPANY    SortField = 4
+	// Requires geo_distance.
+	SortField_SORT_FIELD_DISTANCE SortField = 5
+)
+
+// Enum value maps for SortField.
+var (
+	SortField_name = map[int32]string{
+		0: "SORT_FIELD_RELEVANCE",
+		1: "SORT_FIELD_CREATED_AT",
+		2: "SORT_FIELD_SALARY",
+		3: "SORT_FIELD_TITLE",
+		4: "SORT_FIELD_COMPANY",
+		5: "SORT_FIELD_DISTANCE",
+	}
+	SortField_value = map[string]int32{
+		"SORT_FIELD_RELEVANCE":  0,
+		"SORT_FIELD_CREATED_AT": 1,
+		"SORT_FIELD_SALARY":     2,
+		"SORT_FIELD_TITLE":      3,
+		"SORT_FIELD_COMPANY":    4,
+		"SORT_FIELD_DISTANCE":   5,
+	}
+)
+
+func (x SortField) Enum() *SortField {
+	p := new(SortField)
+	*p = x
+	return p
+}
+
+func (x SortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_job_proto_enumTypes[5].Descriptor()
+}
+
+func (SortField) Type() protoreflect.EnumType {
+	return &file_proto_job_proto_enumTypes[5]
+}
+
+func (x SortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortField.Descriptor instead.
+func (SortField) EnumDescriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{5}
+}
+
+type SortOrder int32
+
+const (
+	// Descending for relevance, created_at and salary, ascending otherwise.
+	SortOrder_SORT_ORDER_DEFAULT SortOrder = 0
+	SortOrder_SORT_ORDER_ASC     SortOrder = 1
+	SortOrder_SORT_ORDER_DESC    SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_DEFAULT",
+		1: "SORT_ORDER_ASC",
+		2: "SORT_ORDER_DESC",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_DEFAULT": 0,
+		"SORT_ORDER_ASC":     1,
+		"SORT_ORDER_DESC":    2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_job_proto_enumTypes[6].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_proto_job_proto_enumTypes[6]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{6}
+}
+
+type FacetField int32
+
+const (
+	FacetField_FACET_FIELD_UNSPECIFIED     FacetField = 0
+	FacetField_FACET_FIELD_SKILLS          FacetField = 1
+	FacetField_FACET_FIELD_LOCATION        FacetField = 2
+	FacetField_FACET_FIELD_COMPANY         FacetField = 3
+	FacetField_FACET_FIELD_SALARY          FacetField = 4
+	FacetField_FACET_FIELD_CREATED_AT      FacetField = 5
+	FacetField_FACET_FIELD_EMPLOYMENT_TYPE FacetField = 6
+	FacetField_FACET_FIELD_SENIORITY       FacetField = 7
+)
+
+// Enum value maps for FacetField.
+var (
+	FacetField_name = map[int32]string{
+		0: "FACET_FIELD_UNSPECIFIED",
+		1: "FACET_FIELD_SKILLS",
+		2: "FACET_FIELD_LOCATION",
+		3: "FACET_FIELD_COMPANY",
+		4: "FACET_FIELD_SALARY",
+		5: "FACET_FIELD_CREATED_AT",
+		6: "FACET_FIELD_EMPLOYMENT_TYPE",
+		7: "FACET_FIELD_SENIORITY",
+	}
+	FacetField_value = map[string]int32{
+		"FACET_FIELD_UNSPECIFIED":     0,
+		"FACET_FIELD_SKILLS":          1,
+		"FACET_FIELD_LOCATION":        2,
+		"FACET_FIELD_COMPANY":         3,
+		"FACET_FIELD_SALARY":          4,
+		"FACET_FIELD_CREATED_AT":      5,
+		"FACET_FIELD_EMPLOYMENT_TYPE": 6,
+		"FACET_FIELD_SENIORITY":       7,
+	}
+)
+
+func (x FacetField) Enum() *FacetField {
+	p := new(FacetField)
+	*p = x
+	return p
+}
+
+func (x FacetField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FacetField) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_job_proto_enumTypes[7].Descriptor()
+}
+
+func (FacetField) Type() protoreflect.EnumType {
+	return &file_proto_job_proto_enumTypes[7]
+}
+
+func (x FacetField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FacetField.Descriptor instead.
+func (FacetField) EnumDescriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{7}
+}
+
+type SuggestField int32
+
+const (
+	SuggestField_SUGGEST_FIELD_TITLE   SuggestField = 0
+	SuggestField_SUGGEST_FIELD_COMPANY SuggestField = 1
+	SuggestField_SUGGEST_FIELD_SKILL   SuggestField = 2
+)
+
+// Enum value maps for SuggestField.
+var (
+	SuggestField_name = map[int32]string{
+		0: "SUGGEST_FIELD_TITLE",
+		1: "SUGGEST_FIELD_COMPANY",
+		2: "SUGGEST_FIELD_SKILL",
+	}
+	SuggestField_value = map[string]int32{
+		"SUGGEST_FIELD_TITLE":   0,
+		"SUGGEST_FIELD_COMPANY": 1,
+		"SUGGEST_FIELD_SKILL":   2,
+	}
+)
+
+func (x SuggestField) Enum() *SuggestField {
+	p := new(SuggestField)
+	*p = x
+	return p
+}
+
+func (x SuggestField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SuggestField) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_job_proto_enumTypes[8].Descriptor()
+}
+
+func (SuggestField) Type() protoreflect.EnumType {
+	return &file_proto_job_proto_enumTypes[8]
+}
+
+func (x SuggestField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SuggestField.Descriptor instead.
+func (SuggestField) EnumDescriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{8}
+}
+
 type Job struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Company       string                 `protobuf:"bytes,4,opt,name=company,proto3" json:"company,omitempty"`
-	Location      string                 `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
-	Skills        []string               `protobuf:"bytes,6,rep,name=skills,proto3" json:"skills,omitempty"`
-	Salary        float64                `protobuf:"fixed64,7,opt,name=salary,proto3" json:"salary,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Score         float64                `protobuf:"fixed64,9,opt,name=score,proto3" json:"score,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Company     string                 `protobuf:"bytes,4,opt,name=company,proto3" json:"company,omitempty"`
+	Location    string                 `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	Skills      []string               `protobuf:"bytes,6,rep,name=skills,proto3" json:"skills,omitempty"`
+	Salary      float64                `protobuf:"fixed64,7,opt,name=salary,proto3" json:"salary,omitempty"`
+	CreatedAt   string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Score       float64                `protobuf:"fixed64,9,opt,name=score,proto3" json:"score,omitempty"`
+	UpdatedAt   string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version     string                 `protobuf:"bytes,11,opt,name=version,proto3" json:"version,omitempty"`
+	// Highlighted fragments keyed by field (title, description, company). Only
+	// set when highlighting was requested.
+	Highlights map[string]*HighlightFragments `protobuf:"bytes,12,rep,name=highlights,proto3" json:"highlights,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Geo        *GeoPoint                      `protobuf:"bytes,13,opt,name=geo,proto3" json:"geo,omitempty"`
+	// Distance in kilometres from the origin of a geo search.
+	DistanceKm   float64       `protobuf:"fixed64,14,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	WorkMode     WorkMode      `protobuf:"varint,15,opt,name=work_mode,json=workMode,proto3,enum=job.WorkMode" json:"work_mode,omitempty"`
+	RemotePolicy *RemotePolicy `protobuf:"bytes,16,opt,name=remote_policy,json=remotePolicy,proto3" json:"remote_policy,omitempty"`
+	// Salary range in currency per pay_period.
+	SalaryMin float64 `protobuf:"fixed64,17,opt,name=salary_min,json=salaryMin,proto3" json:"salary_min,omitempty"`
+	SalaryMax float64 `protobuf:"fixed64,18,opt,name=salary_max,json=salaryMax,proto3" json:"salary_max,omitempty"`
+	// ISO 4217 code. Defaults to the base currency.
+	Currency       string         `protobuf:"bytes,19,opt,name=currency,proto3" json:"currency,omitempty"`
+	PayPeriod      PayPeriod      `protobuf:"varint,20,opt,name=pay_period,json=payPeriod,proto3,enum=job.PayPeriod" json:"pay_period,omitempty"`
+	EmploymentType EmploymentType `protobuf:"varint,21,opt,name=employment_type,json=employmentType,proto3,enum=job.EmploymentType" json:"employment_type,omitempty"`
+	Seniority      Seniority      `protobuf:"varint,22,opt,name=seniority,proto3,enum=job.Seniority" json:"seniority,omitempty"`
+	// Output only: the salary range as a yearly amount in the base currency.
+	AnnualSalaryMin float64 `protobuf:"fixed64,23,opt,name=annual_salary_min,json=annualSalaryMin,proto3" json:"annual_salary_min,omitempty"`
+	AnnualSalaryMax float64 `protobuf:"fixed64,24,opt,name=annual_salary_max,json=annualSalaryMax,proto3" json:"annual_salary_max,omitempty"`
+	// Requested skills the job has, in request order. Only set in search
+	// results.
+	MatchedSkills []string `protobuf:"bytes,25,rep,name=matched_skills,json=matchedSkills,proto3" json:"matched_skills,omitempty"`
+	// Featured jobs may be ranked higher, depending on the ranking profile.
+	Featured bool `protobuf:"varint,26,opt,name=featured,proto3" json:"featured,omitempty"`
+	// How the score was computed. Only set for searches with explain.
+	Explanation   *Explanation `protobuf:"bytes,27,opt,name=explanation,proto3" json:"explanation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -43,14 +562,1823 @@ func (x *Job) Reset() {
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Job) String() string {
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Job) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Job) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Job) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Job) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *Job) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Job) GetSkills() []string {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+func (x *Job) GetSalary() float64 {
+	if x != nil {
+		return x.Salary
+	}
+	return 0
+}
+
+func (x *Job) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Job) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Job) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Job) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Job) GetHighlights() map[string]*HighlightFragments {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+func (x *Job) GetGeo() *GeoPoint {
+	if x != nil {
+		return x.Geo
+	}
+	return nil
+}
+
+func (x *Job) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *Job) GetWorkMode() WorkMode {
+	if x != nil {
+		return x.WorkMode
+	}
+	return WorkMode_WORK_MODE_UNSPECIFIED
+}
+
+func (x *Job) GetRemotePolicy() *RemotePolicy {
+	if x != nil {
+		return x.RemotePolicy
+	}
+	return nil
+}
+
+func (x *Job) GetSalaryMin() float64 {
+	if x != nil {
+		return x.SalaryMin
+	}
+	return 0
+}
+
+func (x *Job) GetSalaryMax() float64 {
+	if x != nil {
+		return x.SalaryMax
+	}
+	return 0
+}
+
+func (x *Job) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Job) GetPayPeriod() PayPeriod {
+	if x != nil {
+		return x.PayPeriod
+	}
+	return PayPeriod_PAY_PERIOD_UNSPECIFIED
+}
+
+func (x *Job) GetEmploymentType() EmploymentType {
+	if x != nil {
+		return x.EmploymentType
+	}
+	return EmploymentType_EMPLOYMENT_TYPE_UNSPECIFIED
+}
+
+func (x *Job) GetSeniority() Seniority {
+	if x != nil {
+		return x.Seniority
+	}
+	return Seniority_SENIORITY_UNSPECIFIED
+}
+
+func (x *Job) GetAnnualSalaryMin() float64 {
+	if x != nil {
+		return x.AnnualSalaryMin
+	}
+	return 0
+}
+
+func (x *Job) GetAnnualSalaryMax() float64 {
+	if x != nil {
+		return x.AnnualSalaryMax
+	}
+	return 0
+}
+
+func (x *Job) GetMatchedSkills() []string {
+	if x != nil {
+		return x.MatchedSkills
+	}
+	return nil
+}
+
+func (x *Job) GetFeatured() bool {
+	if x != nil {
+		return x.Featured
+	}
+	return false
+}
+
+func (x *Job) GetExplanation() *Explanation {
+	if x != nil {
+		return x.Explanation
+	}
+	return nil
+}
+
+// Explanation is the Elasticsearch scoring explanation of a search hit: value
+// is computed from the details as described.
+type Explanation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         float64                `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Details       []*Explanation         `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Explanation) Reset() {
+	*x = Explanation{}
+	mi := &file_proto_job_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Explanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Explanation) ProtoMessage() {}
+
+func (x *Explanation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Explanation.ProtoReflect.Descriptor instead.
+func (*Explanation) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Explanation) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Explanation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Explanation) GetDetails() []*Explanation {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// Where candidates of a remote job may be based. Unset fields mean no
+// restriction.
+type RemotePolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ISO 3166-1 alpha-2 country codes.
+	AllowedCountries    []string `protobuf:"bytes,1,rep,name=allowed_countries,json=allowedCountries,proto3" json:"allowed_countries,omitempty"`
+	MinUtcOffsetMinutes *int32   `protobuf:"varint,2,opt,name=min_utc_offset_minutes,json=minUtcOffsetMinutes,proto3,oneof" json:"min_utc_offset_minutes,omitempty"`
+	MaxUtcOffsetMinutes *int32   `protobuf:"varint,3,opt,name=max_utc_offset_minutes,json=maxUtcOffsetMinutes,proto3,oneof" json:"max_utc_offset_minutes,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *RemotePolicy) Reset() {
+	*x = RemotePolicy{}
+	mi := &file_proto_job_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemotePolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemotePolicy) ProtoMessage() {}
+
+func (x *RemotePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemotePolicy.ProtoReflect.Descriptor instead.
+func (*RemotePolicy) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RemotePolicy) GetAllowedCountries() []string {
+	if x != nil {
+		return x.AllowedCountries
+	}
+	return nil
+}
+
+func (x *RemotePolicy) GetMinUtcOffsetMinutes() int32 {
+	if x != nil && x.MinUtcOffsetMinutes != nil {
+		return *x.MinUtcOffsetMinutes
+	}
+	return 0
+}
+
+func (x *RemotePolicy) GetMaxUtcOffsetMinutes() int32 {
+	if x != nil && x.MaxUtcOffsetMinutes != nil {
+		return *x.MaxUtcOffsetMinutes
+	}
+	return 0
+}
+
+type GeoPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lat           float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon           float64                `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoPoint) Reset() {
+	*x = GeoPoint{}
+	mi := &file_proto_job_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoPoint) ProtoMessage() {}
+
+func (x *GeoPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoPoint.ProtoReflect.Descriptor instead.
+func (*GeoPoint) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GeoPoint) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *GeoPoint) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+type HighlightFragments struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fragments     []string               `protobuf:"bytes,1,rep,name=fragments,proto3" json:"fragments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HighlightFragments) Reset() {
+	*x = HighlightFragments{}
+	mi := &file_proto_job_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HighlightFragments) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HighlightFragments) ProtoMessage() {}
+
+func (x *HighlightFragments) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HighlightFragments.ProtoReflect.Descriptor instead.
+func (*HighlightFragments) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HighlightFragments) GetFragments() []string {
+	if x != nil {
+		return x.Fragments
+	}
+	return nil
+}
+
+type CreateJobRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Company     string                 `protobuf:"bytes,3,opt,name=company,proto3" json:"company,omitempty"`
+	Location    string                 `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	Skills      []string               `protobuf:"bytes,5,rep,name=skills,proto3" json:"skills,omitempty"`
+	Salary      float64                `protobuf:"fixed64,6,opt,name=salary,proto3" json:"salary,omitempty"`
+	// Geocoded from location when not set.
+	Geo      *GeoPoint `protobuf:"bytes,7,opt,name=geo,proto3" json:"geo,omitempty"`
+	WorkMode WorkMode  `protobuf:"varint,8,opt,name=work_mode,json=workMode,proto3,enum=job.WorkMode" json:"work_mode,omitempty"`
+	// Only allowed for remote jobs.
+	RemotePolicy   *RemotePolicy  `protobuf:"bytes,9,opt,name=remote_policy,json=remotePolicy,proto3" json:"remote_policy,omitempty"`
+	SalaryMin      float64        `protobuf:"fixed64,10,opt,name=salary_min,json=salaryMin,proto3" json:"salary_min,omitempty"`
+	SalaryMax      float64        `protobuf:"fixed64,11,opt,name=salary_max,json=salaryMax,proto3" json:"salary_max,omitempty"`
+	Currency       string         `protobuf:"bytes,12,opt,name=currency,proto3" json:"currency,omitempty"`
+	PayPeriod      PayPeriod      `protobuf:"varint,13,opt,name=pay_period,json=payPeriod,proto3,enum=job.PayPeriod" json:"pay_period,omitempty"`
+	EmploymentType EmploymentType `protobuf:"varint,14,opt,name=employment_type,json=employmentType,proto3,enum=job.EmploymentType" json:"employment_type,omitempty"`
+	Seniority      Seniority      `protobuf:"varint,15,opt,name=seniority,proto3,enum=job.Seniority" json:"seniority,omitempty"`
+	Featured       bool           `protobuf:"varint,16,opt,name=featured,proto3" json:"featured,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateJobRequest) Reset() {
+	*x = CreateJobRequest{}
+	mi := &file_proto_job_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateJobRequest) ProtoMessage() {}
+
+func (x *CreateJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateJobRequest.ProtoReflect.Descriptor instead.
+func (*CreateJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateJobRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetSkills() []string {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetSalary() float64 {
+	if x != nil {
+		return x.Salary
+	}
+	return 0
+}
+
+func (x *CreateJobRequest) GetGeo() *GeoPoint {
+	if x != nil {
+		return x.Geo
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetWorkMode() WorkMode {
+	if x != nil {
+		return x.WorkMode
+	}
+	return WorkMode_WORK_MODE_UNSPECIFIED
+}
+
+func (x *CreateJobRequest) GetRemotePolicy() *RemotePolicy {
+	if x != nil {
+		return x.RemotePolicy
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetSalaryMin() float64 {
+	if x != nil {
+		return x.SalaryMin
+	}
+	return 0
+}
+
+func (x *CreateJobRequest) GetSalaryMax() float64 {
+	if x != nil {
+		return x.SalaryMax
+	}
+	return 0
+}
+
+func (x *CreateJobRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetPayPeriod() PayPeriod {
+	if x != nil {
+		return x.PayPeriod
+	}
+	return PayPeriod_PAY_PERIOD_UNSPECIFIED
+}
+
+func (x *CreateJobRequest) GetEmploymentType() EmploymentType {
+	if x != nil {
+		return x.EmploymentType
+	}
+	return EmploymentType_EMPLOYMENT_TYPE_UNSPECIFIED
+}
+
+func (x *CreateJobRequest) GetSeniority() Seniority {
+	if x != nil {
+		return x.Seniority
+	}
+	return Seniority_SENIORITY_UNSPECIFIED
+}
+
+func (x *CreateJobRequest) GetFeatured() bool {
+	if x != nil {
+		return x.Featured
+	}
+	return false
+}
+
+type CreateJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateJobResponse) Reset() {
+	*x = CreateJobResponse{}
+	mi := &file_proto_job_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateJobResponse) ProtoMessage() {}
+
+func (x *CreateJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateJobResponse.ProtoReflect.Descriptor instead.
+func (*CreateJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateJobResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateJobResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type BulkCreateJobsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per job, in stream order.
+	Results       []*BulkCreateJobResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	CreatedCount  int32                  `protobuf:"varint,2,opt,name=created_count,json=createdCount,proto3" json:"created_count,omitempty"`
+	FailedCount   int32                  `protobuf:"varint,3,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkCreateJobsResponse) Reset() {
+	*x = BulkCreateJobsResponse{}
+	mi := &file_proto_job_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkCreateJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkCreateJobsResponse) ProtoMessage() {}
+
+func (x *BulkCreateJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkCreateJobsResponse.ProtoReflect.Descriptor instead.
+func (*BulkCreateJobsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BulkCreateJobsResponse) GetResults() []*BulkCreateJobResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BulkCreateJobsResponse) GetCreatedCount() int32 {
+	if x != nil {
+		return x.CreatedCount
+	}
+	return 0
+}
+
+func (x *BulkCreateJobsResponse) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+type BulkCreateJobResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of the job in the stream, starting at 0.
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Set when the job was created.
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Set when it was not: what CreateJob would have failed with.
+	Error         *BulkCreateJobError `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkCreateJobResult) Reset() {
+	*x = BulkCreateJobResult{}
+	mi := &file_proto_job_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkCreateJobResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkCreateJobResult) ProtoMessage() {}
+
+func (x *BulkCreateJobResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkCreateJobResult.ProtoReflect.Descriptor instead.
+func (*BulkCreateJobResult) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BulkCreateJobResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BulkCreateJobResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BulkCreateJobResult) GetError() *BulkCreateJobError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type BulkCreateJobError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// google.rpc.Code, e.g. 3 for INVALID_ARGUMENT.
+	Code    int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// The ErrorInfo reason, e.g. VALIDATION_FAILED.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The BadRequest field violations.
+	FieldViolations []*FieldViolation `protobuf:"bytes,4,rep,name=field_violations,json=fieldViolations,proto3" json:"field_violations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BulkCreateJobError) Reset() {
+	*x = BulkCreateJobError{}
+	mi := &file_proto_job_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkCreateJobError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkCreateJobError) ProtoMessage() {}
+
+func (x *BulkCreateJobError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkCreateJobError.ProtoReflect.Descriptor instead.
+func (*BulkCreateJobError) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BulkCreateJobError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BulkCreateJobError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BulkCreateJobError) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BulkCreateJobError) GetFieldViolations() []*FieldViolation {
+	if x != nil {
+		return x.FieldViolations
+	}
+	return nil
+}
+
+type FieldViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldViolation) Reset() {
+	*x = FieldViolation{}
+	mi := &file_proto_job_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldViolation) ProtoMessage() {}
+
+func (x *FieldViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldViolation.ProtoReflect.Descriptor instead.
+func (*FieldViolation) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FieldViolation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldViolation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type SearchJobsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Query        string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Location     string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Skills       []string               `protobuf:"bytes,3,rep,name=skills,proto3" json:"skills,omitempty"`
+	PageSize     int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken    string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Sort         []*SortOption          `protobuf:"bytes,6,rep,name=sort,proto3" json:"sort,omitempty"`
+	MinSalary    *float64               `protobuf:"fixed64,7,opt,name=min_salary,json=minSalary,proto3,oneof" json:"min_salary,omitempty"`
+	MaxSalary    *float64               `protobuf:"fixed64,8,opt,name=max_salary,json=maxSalary,proto3,oneof" json:"max_salary,omitempty"`
+	PostedAfter  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=posted_after,json=postedAfter,proto3" json:"posted_after,omitempty"`
+	PostedBefore *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=posted_before,json=postedBefore,proto3" json:"posted_before,omitempty"`
+	Facets       []*FacetRequest        `protobuf:"bytes,11,rep,name=facets,proto3" json:"facets,omitempty"`
+	// Highlighting is enabled when set.
+	Highlight *HighlightOptions `protobuf:"bytes,12,opt,name=highlight,proto3" json:"highlight,omitempty"`
+	// Rerun a search without results using suggested_query.
+	AutoCorrect bool               `protobuf:"varint,13,opt,name=auto_correct,json=autoCorrect,proto3" json:"auto_correct,omitempty"`
+	GeoDistance *GeoDistanceFilter `protobuf:"bytes,14,opt,name=geo_distance,json=geoDistance,proto3" json:"geo_distance,omitempty"`
+	WorkModes   []WorkMode         `protobuf:"varint,15,rep,packed,name=work_modes,json=workModes,proto3,enum=job.WorkMode" json:"work_modes,omitempty"`
+	// Country (ISO 3166-1 alpha-2) and UTC offset of the candidate. Remote jobs
+	// whose remote_policy excludes them are filtered out; other jobs are not
+	// affected.
+	RemoteCountry          string           `protobuf:"bytes,16,opt,name=remote_country,json=remoteCountry,proto3" json:"remote_country,omitempty"`
+	RemoteUtcOffsetMinutes *int32           `protobuf:"varint,17,opt,name=remote_utc_offset_minutes,json=remoteUtcOffsetMinutes,proto3,oneof" json:"remote_utc_offset_minutes,omitempty"`
+	EmploymentTypes        []EmploymentType `protobuf:"varint,18,rep,packed,name=employment_types,json=employmentTypes,proto3,enum=job.EmploymentType" json:"employment_types,omitempty"`
+	Seniorities            []Seniority      `protobuf:"varint,19,rep,packed,name=seniorities,proto3,enum=job.Seniority" json:"seniorities,omitempty"`
+	// Currency and pay period of min_salary and max_salary, which match jobs
+	// whose salary range overlaps them. Default to the base currency per year.
+	SalaryCurrency string    `protobuf:"bytes,20,opt,name=salary_currency,json=salaryCurrency,proto3" json:"salary_currency,omitempty"`
+	SalaryPeriod   PayPeriod `protobuf:"varint,21,opt,name=salary_period,json=salaryPeriod,proto3,enum=job.PayPeriod" json:"salary_period,omitempty"`
+	// How many of skills a job needs. Every matched skill adds to the score.
+	SkillsMatchMode SkillsMatchMode `protobuf:"varint,22,opt,name=skills_match_mode,json=skillsMatchMode,proto3,enum=job.SkillsMatchMode" json:"skills_match_mode,omitempty"`
+	// Number of skills required with SKILLS_MATCH_MODE_AT_LEAST_N.
+	SkillsMinimumMatch int32 `protobuf:"varint,23,opt,name=skills_minimum_match,json=skillsMinimumMatch,proto3" json:"skills_minimum_match,omitempty"`
+	// Name of a ranking profile from the server config. Defaults to the
+	// configured default profile.
+	RankingProfile string `protobuf:"bytes,24,opt,name=ranking_profile,json=rankingProfile,proto3" json:"ranking_profile,omitempty"`
+	// Return the scoring explanation of every hit. Requires the admin token in
+	// the x-admin-token metadata.
+	Explain       bool `protobuf:"varint,25,opt,name=explain,proto3" json:"explain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchJobsRequest) Reset() {
+	*x = SearchJobsRequest{}
+	mi := &file_proto_job_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchJobsRequest) ProtoMessage() {}
+
+func (x *SearchJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchJobsRequest.ProtoReflect.Descriptor instead.
+func (*SearchJobsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchJobsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchJobsRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *SearchJobsRequest) GetSkills() []string {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchJobsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchJobsRequest) GetSort() []*SortOption {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetMinSalary() float64 {
+	if x != nil && x.MinSalary != nil {
+		return *x.MinSalary
+	}
+	return 0
+}
+
+func (x *SearchJobsRequest) GetMaxSalary() float64 {
+	if x != nil && x.MaxSalary != nil {
+		return *x.MaxSalary
+	}
+	return 0
+}
+
+func (x *SearchJobsRequest) GetPostedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PostedAfter
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetPostedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PostedBefore
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetFacets() []*FacetRequest {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetHighlight() *HighlightOptions {
+	if x != nil {
+		return x.Highlight
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetAutoCorrect() bool {
+	if x != nil {
+		return x.AutoCorrect
+	}
+	return false
+}
+
+func (x *SearchJobsRequest) GetGeoDistance() *GeoDistanceFilter {
+	if x != nil {
+		return x.GeoDistance
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetWorkModes() []WorkMode {
+	if x != nil {
+		return x.WorkModes
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetRemoteCountry() string {
+	if x != nil {
+		return x.RemoteCountry
+	}
+	return ""
+}
+
+func (x *SearchJobsRequest) GetRemoteUtcOffsetMinutes() int32 {
+	if x != nil && x.RemoteUtcOffsetMinutes != nil {
+		return *x.RemoteUtcOffsetMinutes
+	}
+	return 0
+}
+
+func (x *SearchJobsRequest) GetEmploymentTypes() []EmploymentType {
+	if x != nil {
+		return x.EmploymentTypes
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetSeniorities() []Seniority {
+	if x != nil {
+		return x.Seniorities
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetSalaryCurrency() string {
+	if x != nil {
+		return x.SalaryCurrency
+	}
+	return ""
+}
+
+func (x *SearchJobsRequest) GetSalaryPeriod() PayPeriod {
+	if x != nil {
+		return x.SalaryPeriod
+	}
+	return PayPeriod_PAY_PERIOD_UNSPECIFIED
+}
+
+func (x *SearchJobsRequest) GetSkillsMatchMode() SkillsMatchMode {
+	if x != nil {
+		return x.SkillsMatchMode
+	}
+	return SkillsMatchMode_SKILLS_MATCH_MODE_ANY
+}
+
+func (x *SearchJobsRequest) GetSkillsMinimumMatch() int32 {
+	if x != nil {
+		return x.SkillsMinimumMatch
+	}
+	return 0
+}
+
+func (x *SearchJobsRequest) GetRankingProfile() string {
+	if x != nil {
+		return x.RankingProfile
+	}
+	return ""
+}
+
+func (x *SearchJobsRequest) GetExplain() bool {
+	if x != nil {
+		return x.Explain
+	}
+	return false
+}
+
+type GeoDistanceFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Either origin or origin_location (a city name) must be set.
+	Origin         *GeoPoint `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
+	OriginLocation string    `protobuf:"bytes,2,opt,name=origin_location,json=originLocation,proto3" json:"origin_location,omitempty"`
+	// Only return jobs within this distance. Zero disables the filter and only
+	// sets the origin for distance sorting and distance_km.
+	RadiusKm      float64 `protobuf:"fixed64,3,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoDistanceFilter) Reset() {
+	*x = GeoDistanceFilter{}
+	mi := &file_proto_job_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoDistanceFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoDistanceFilter) ProtoMessage() {}
+
+func (x *GeoDistanceFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoDistanceFilter.ProtoReflect.Descriptor instead.
+func (*GeoDistanceFilter) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GeoDistanceFilter) GetOrigin() *GeoPoint {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *GeoDistanceFilter) GetOriginLocation() string {
+	if x != nil {
+		return x.OriginLocation
+	}
+	return ""
+}
+
+func (x *GeoDistanceFilter) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+type HighlightOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to "<em>".
+	PreTag string `protobuf:"bytes,1,opt,name=pre_tag,json=preTag,proto3" json:"pre_tag,omitempty"`
+	// Defaults to "</em>".
+	PostTag string `protobuf:"bytes,2,opt,name=post_tag,json=postTag,proto3" json:"post_tag,omitempty"`
+	// Size of description fragments in characters. Defaults to 150.
+	FragmentSize int32 `protobuf:"varint,3,opt,name=fragment_size,json=fragmentSize,proto3" json:"fragment_size,omitempty"`
+	// Maximum number of description fragments. Defaults to 3.
+	NumberOfFragments int32 `protobuf:"varint,4,opt,name=number_of_fragments,json=numberOfFragments,proto3" json:"number_of_fragments,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *HighlightOptions) Reset() {
+	*x = HighlightOptions{}
+	mi := &file_proto_job_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HighlightOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HighlightOptions) ProtoMessage() {}
+
+func (x *HighlightOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HighlightOptions.ProtoReflect.Descriptor instead.
+func (*HighlightOptions) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *HighlightOptions) GetPreTag() string {
+	if x != nil {
+		return x.PreTag
+	}
+	return ""
+}
+
+func (x *HighlightOptions) GetPostTag() string {
+	if x != nil {
+		return x.PostTag
+	}
+	return ""
+}
+
+func (x *HighlightOptions) GetFragmentSize() int32 {
+	if x != nil {
+		return x.FragmentSize
+	}
+	return 0
+}
+
+func (x *HighlightOptions) GetNumberOfFragments() int32 {
+	if x != nil {
+		return x.NumberOfFragments
+	}
+	return 0
+}
+
+type SortOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         SortField              `protobuf:"varint,1,opt,name=field,proto3,enum=job.SortField" json:"field,omitempty"`
+	Order         SortOrder              `protobuf:"varint,2,opt,name=order,proto3,enum=job.SortOrder" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SortOption) Reset() {
+	*x = SortOption{}
+	mi := &file_proto_job_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SortOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortOption) ProtoMessage() {}
+
+func (x *SortOption) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortOption.ProtoReflect.Descriptor instead.
+func (*SortOption) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SortOption) GetField() SortField {
+	if x != nil {
+		return x.Field
+	}
+	return SortField_SORT_FIELD_RELEVANCE
+}
+
+func (x *SortOption) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_DEFAULT
+}
+
+type SearchJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Keyed by facet name: skills, location, company, salary, created_at,
+	// employment_type, seniority.
+	Facets map[string]*FacetResult `protobuf:"bytes,4,rep,name=facets,proto3" json:"facets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Spelling correction of the query when it matched no jobs.
+	SuggestedQuery string `protobuf:"bytes,5,opt,name=suggested_query,json=suggestedQuery,proto3" json:"suggested_query,omitempty"`
+	// Set when auto_correct was requested and the jobs are the results of
+	// suggested_query.
+	QueryCorrected bool `protobuf:"varint,6,opt,name=query_corrected,json=queryCorrected,proto3" json:"query_corrected,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchJobsResponse) Reset() {
+	*x = SearchJobsResponse{}
+	mi := &file_proto_job_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchJobsResponse) ProtoMessage() {}
+
+func (x *SearchJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchJobsResponse.ProtoReflect.Descriptor instead.
+func (*SearchJobsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SearchJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *SearchJobsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SearchJobsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *SearchJobsResponse) GetFacets() map[string]*FacetResult {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *SearchJobsResponse) GetSuggestedQuery() string {
+	if x != nil {
+		return x.SuggestedQuery
+	}
+	return ""
+}
+
+func (x *SearchJobsResponse) GetQueryCorrected() bool {
+	if x != nil {
+		return x.QueryCorrected
+	}
+	return false
+}
+
+type FacetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Field FacetField             `protobuf:"varint,1,opt,name=field,proto3,enum=job.FacetField" json:"field,omitempty"`
+	// Maximum number of buckets for skills, location, company,
+	// employment_type and seniority. Defaults to 10.
+	Size int32 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// Bucket width of the salary histogram. Defaults to 10000.
+	Interval float64 `protobuf:"fixed64,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Bucket width of the created_at histogram: day, week, month (default),
+	// quarter or year.
+	CalendarInterval string `protobuf:"bytes,4,opt,name=calendar_interval,json=calendarInterval,proto3" json:"calendar_interval,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FacetRequest) Reset() {
+	*x = FacetRequest{}
+	mi := &file_proto_job_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetRequest) ProtoMessage() {}
+
+func (x *FacetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetRequest.ProtoReflect.Descriptor instead.
+func (*FacetRequest) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FacetRequest) GetField() FacetField {
+	if x != nil {
+		return x.Field
+	}
+	return FacetField_FACET_FIELD_UNSPECIFIED
+}
+
+func (x *FacetRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FacetRequest) GetInterval() float64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *FacetRequest) GetCalendarInterval() string {
+	if x != nil {
+		return x.CalendarInterval
+	}
+	return ""
+}
+
+type FacetBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacetBucket) Reset() {
+	*x = FacetBucket{}
+	mi := &file_proto_job_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetBucket) ProtoMessage() {}
+
+func (x *FacetBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetBucket.ProtoReflect.Descriptor instead.
+func (*FacetBucket) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FacetBucket) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *FacetBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type FacetResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Buckets       []*FacetBucket         `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacetResult) Reset() {
+	*x = FacetResult{}
+	mi := &file_proto_job_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetResult) ProtoMessage() {}
+
+func (x *FacetResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetResult.ProtoReflect.Descriptor instead.
+func (*FacetResult) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FacetResult) GetBuckets() []*FacetBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type GetJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	mi := &file_proto_job_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobResponse) Reset() {
+	*x = GetJobResponse{}
+	mi := &file_proto_job_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobResponse) ProtoMessage() {}
+
+func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
+func (*GetJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type DeleteJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJobRequest) Reset() {
+	*x = DeleteJobRequest{}
+	mi := &file_proto_job_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobRequest) ProtoMessage() {}
+
+func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobRequest.ProtoReflect.Descriptor instead.
+func (*DeleteJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteJobRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type DeleteJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJobResponse) Reset() {
+	*x = DeleteJobResponse{}
+	mi := &file_proto_job_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobResponse) ProtoMessage() {}
+
+func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobResponse.ProtoReflect.Descriptor instead.
+func (*DeleteJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteJobResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type UpdateJobRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Company     string                 `protobuf:"bytes,4,opt,name=company,proto3" json:"company,omitempty"`
+	Location    string                 `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	Skills      []string               `protobuf:"bytes,6,rep,name=skills,proto3" json:"skills,omitempty"`
+	Salary      float64                `protobuf:"fixed64,7,opt,name=salary,proto3" json:"salary,omitempty"`
+	Version     string                 `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`
+	// Geocoded from location when not set.
+	Geo      *GeoPoint `protobuf:"bytes,9,opt,name=geo,proto3" json:"geo,omitempty"`
+	WorkMode WorkMode  `protobuf:"varint,10,opt,name=work_mode,json=workMode,proto3,enum=job.WorkMode" json:"work_mode,omitempty"`
+	// Only allowed for remote jobs.
+	RemotePolicy   *RemotePolicy  `protobuf:"bytes,11,opt,name=remote_policy,json=remotePolicy,proto3" json:"remote_policy,omitempty"`
+	SalaryMin      float64        `protobuf:"fixed64,12,opt,name=salary_min,json=salaryMin,proto3" json:"salary_min,omitempty"`
+	SalaryMax      float64        `protobuf:"fixed64,13,opt,name=salary_max,json=salaryMax,proto3" json:"salary_max,omitempty"`
+	Currency       string         `protobuf:"bytes,14,opt,name=currency,proto3" json:"currency,omitempty"`
+	PayPeriod      PayPeriod      `protobuf:"varint,15,opt,name=pay_period,json=payPeriod,proto3,enum=job.PayPeriod" json:"pay_period,omitempty"`
+	EmploymentType EmploymentType `protobuf:"varint,16,opt,name=employment_type,json=employmentType,proto3,enum=job.EmploymentType" json:"employment_type,omitempty"`
+	Seniority      Seniority      `protobuf:"varint,17,opt,name=seniority,proto3,enum=job.Seniority" json:"seniority,omitempty"`
+	Featured       bool           `protobuf:"varint,18,opt,name=featured,proto3" json:"featured,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateJobRequest) Reset() {
+	*x = UpdateJobRequest{}
+	mi := &file_proto_job_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateJobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Job) ProtoMessage() {}
+func (*UpdateJobRequest) ProtoMessage() {}
 
-func (x *Job) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_job_proto_msgTypes[0]
+func (x *UpdateJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -61,181 +2389,160 @@ func (x *Job) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Job.ProtoReflect.Descriptor instead.
-func (*Job) Descriptor() ([]byte, []int) {
-	return file_proto_job_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use UpdateJobRequest.ProtoReflect.Descriptor instead.
+func (*UpdateJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *Job) GetId() string {
+func (x *UpdateJobRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Job) GetTitle() string {
+func (x *UpdateJobRequest) GetTitle() string {
 	if x != nil {
 		return x.Title
 	}
 	return ""
 }
 
-func (x *Job) GetDescription() string {
+func (x *UpdateJobRequest) GetDescription() string {
 	if x != nil {
 		return x.Description
 	}
 	return ""
 }
 
-func (x *Job) GetCompany() string {
+func (x *UpdateJobRequest) GetCompany() string {
 	if x != nil {
 		return x.Company
 	}
 	return ""
 }
 
-func (x *Job) GetLocation() string {
+func (x *UpdateJobRequest) GetLocation() string {
 	if x != nil {
 		return x.Location
 	}
 	return ""
 }
 
-func (x *Job) GetSkills() []string {
+func (x *UpdateJobRequest) GetSkills() []string {
 	if x != nil {
 		return x.Skills
 	}
 	return nil
 }
 
-func (x *Job) GetSalary() float64 {
+func (x *UpdateJobRequest) GetSalary() float64 {
 	if x != nil {
 		return x.Salary
 	}
 	return 0
 }
 
-func (x *Job) GetCreatedAt() string {
+func (x *UpdateJobRequest) GetVersion() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Version
 	}
 	return ""
 }
 
-func (x *Job) GetScore() float64 {
+func (x *UpdateJobRequest) GetGeo() *GeoPoint {
 	if x != nil {
-		return x.Score
+		return x.Geo
 	}
-	return 0
-}
-
-type CreateJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Company       string                 `protobuf:"bytes,3,opt,name=company,proto3" json:"company,omitempty"`
-	Location      string                 `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
-	Skills        []string               `protobuf:"bytes,5,rep,name=skills,proto3" json:"skills,omitempty"`
-	Salary        float64                `protobuf:"fixed64,6,opt,name=salary,proto3" json:"salary,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CreateJobRequest) Reset() {
-	*x = CreateJobRequest{}
-	mi := &file_proto_job_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return nil
 }
 
-func (x *CreateJobRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UpdateJobRequest) GetWorkMode() WorkMode {
+	if x != nil {
+		return x.WorkMode
+	}
+	return WorkMode_WORK_MODE_UNSPECIFIED
 }
 
-func (*CreateJobRequest) ProtoMessage() {}
-
-func (x *CreateJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_job_proto_msgTypes[1]
+func (x *UpdateJobRequest) GetRemotePolicy() *RemotePolicy {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.RemotePolicy
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use CreateJobRequest.ProtoReflect.Descriptor instead.
-func (*CreateJobRequest) Descriptor() ([]byte, []int) {
-	return file_proto_job_proto_rawDescGZIP(), []int{1}
+func (x *UpdateJobRequest) GetSalaryMin() float64 {
+	if x != nil {
+		return x.SalaryMin
+	}
+	return 0
 }
 
-func (x *CreateJobRequest) GetTitle() string {
+func (x *UpdateJobRequest) GetSalaryMax() float64 {
 	if x != nil {
-		return x.Title
+		return x.SalaryMax
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateJobRequest) GetDescription() string {
+func (x *UpdateJobRequest) GetCurrency() string {
 	if x != nil {
-		return x.Description
+		return x.Currency
 	}
 	return ""
 }
 
-func (x *CreateJobRequest) GetCompany() string {
+func (x *UpdateJobRequest) GetPayPeriod() PayPeriod {
 	if x != nil {
-		return x.Company
+		return x.PayPeriod
 	}
-	return ""
+	return PayPeriod_PAY_PERIOD_UNSPECIFIED
 }
 
-func (x *CreateJobRequest) GetLocation() string {
+func (x *UpdateJobRequest) GetEmploymentType() EmploymentType {
 	if x != nil {
-		return x.Location
+		return x.EmploymentType
 	}
-	return ""
+	return EmploymentType_EMPLOYMENT_TYPE_UNSPECIFIED
 }
 
-func (x *CreateJobRequest) GetSkills() []string {
+func (x *UpdateJobRequest) GetSeniority() Seniority {
 	if x != nil {
-		return x.Skills
+		return x.Seniority
 	}
-	return nil
+	return Seniority_SENIORITY_UNSPECIFIED
 }
 
-func (x *CreateJobRequest) GetSalary() float64 {
+func (x *UpdateJobRequest) GetFeatured() bool {
 	if x != nil {
-		return x.Salary
+		return x.Featured
 	}
-	return 0
+	return false
 }
 
-type CreateJobResponse struct {
+type UpdateJobResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateJobResponse) Reset() {
-	*x = CreateJobResponse{}
-	mi := &file_proto_job_proto_msgTypes[2]
+func (x *UpdateJobResponse) Reset() {
+	*x = UpdateJobResponse{}
+	mi := &file_proto_job_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateJobResponse) String() string {
+func (x *UpdateJobResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateJobResponse) ProtoMessage() {}
+func (*UpdateJobResponse) ProtoMessage() {}
 
-func (x *CreateJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_job_proto_msgTypes[2]
+func (x *UpdateJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -246,49 +2553,50 @@ func (x *CreateJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateJobResponse.ProtoReflect.Descriptor instead.
-func (*CreateJobResponse) Descriptor() ([]byte, []int) {
-	return file_proto_job_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use UpdateJobResponse.ProtoReflect.Descriptor instead.
+func (*UpdateJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *CreateJobResponse) GetId() string {
+func (x *UpdateJobResponse) GetJob() *Job {
 	if x != nil {
-		return x.Id
+		return x.Job
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateJobResponse) GetMessage() string {
+func (x *UpdateJobResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-type SearchJobsRequest struct {
+type PatchJobRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	Location      string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
-	Skills        []string               `protobuf:"bytes,3,rep,name=skills,proto3" json:"skills,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Job           *Job                   `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	Version       string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchJobsRequest) Reset() {
-	*x = SearchJobsRequest{}
-	mi := &file_proto_job_proto_msgTypes[3]
+func (x *PatchJobRequest) Reset() {
+	*x = PatchJobRequest{}
+	mi := &file_proto_job_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchJobsRequest) String() string {
+func (x *PatchJobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchJobsRequest) ProtoMessage() {}
+func (*PatchJobRequest) ProtoMessage() {}
 
-func (x *SearchJobsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_job_proto_msgTypes[3]
+func (x *PatchJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -299,55 +2607,62 @@ func (x *SearchJobsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchJobsRequest.ProtoReflect.Descriptor instead.
-func (*SearchJobsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_job_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use PatchJobRequest.ProtoReflect.Descriptor instead.
+func (*PatchJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *SearchJobsRequest) GetQuery() string {
+func (x *PatchJobRequest) GetId() string {
 	if x != nil {
-		return x.Query
+		return x.Id
 	}
 	return ""
 }
 
-func (x *SearchJobsRequest) GetLocation() string {
+func (x *PatchJobRequest) GetJob() *Job {
 	if x != nil {
-		return x.Location
+		return x.Job
 	}
-	return ""
+	return nil
 }
 
-func (x *SearchJobsRequest) GetSkills() []string {
+func (x *PatchJobRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
 	if x != nil {
-		return x.Skills
+		return x.UpdateMask
 	}
 	return nil
 }
 
-type SearchJobsResponse struct {
+func (x *PatchJobRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type PatchJobResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchJobsResponse) Reset() {
-	*x = SearchJobsResponse{}
-	mi := &file_proto_job_proto_msgTypes[4]
+func (x *PatchJobResponse) Reset() {
+	*x = PatchJobResponse{}
+	mi := &file_proto_job_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchJobsResponse) String() string {
+func (x *PatchJobResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchJobsResponse) ProtoMessage() {}
+func (*PatchJobResponse) ProtoMessage() {}
 
-func (x *SearchJobsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_job_proto_msgTypes[4]
+func (x *PatchJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -358,47 +2673,50 @@ func (x *SearchJobsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchJobsResponse.ProtoReflect.Descriptor instead.
-func (*SearchJobsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_job_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use PatchJobResponse.ProtoReflect.Descriptor instead.
+func (*PatchJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *SearchJobsResponse) GetJobs() []*Job {
+func (x *PatchJobResponse) GetJob() *Job {
 	if x != nil {
-		return x.Jobs
+		return x.Job
 	}
 	return nil
 }
 
-func (x *SearchJobsResponse) GetTotal() int32 {
+func (x *PatchJobResponse) GetMessage() string {
 	if x != nil {
-		return x.Total
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-type GetJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+type SuggestJobsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Field  SuggestField           `protobuf:"varint,2,opt,name=field,proto3,enum=job.SuggestField" json:"field,omitempty"`
+	// Maximum number of suggestions. Defaults to 5, capped at 20.
+	Size          int32 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetJobRequest) Reset() {
-	*x = GetJobRequest{}
-	mi := &file_proto_job_proto_msgTypes[5]
+func (x *SuggestJobsRequest) Reset() {
+	*x = SuggestJobsRequest{}
+	mi := &file_proto_job_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetJobRequest) String() string {
+func (x *SuggestJobsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobRequest) ProtoMessage() {}
+func (*SuggestJobsRequest) ProtoMessage() {}
 
-func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_job_proto_msgTypes[5]
+func (x *SuggestJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -409,84 +2727,56 @@ func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
-func (*GetJobRequest) Descriptor() ([]byte, []int) {
-	return file_proto_job_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use SuggestJobsRequest.ProtoReflect.Descriptor instead.
+func (*SuggestJobsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *GetJobRequest) GetId() string {
+func (x *SuggestJobsRequest) GetPrefix() string {
 	if x != nil {
-		return x.Id
+		return x.Prefix
 	}
 	return ""
 }
 
-type GetJobResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetJobResponse) Reset() {
-	*x = GetJobResponse{}
-	mi := &file_proto_job_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetJobResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetJobResponse) ProtoMessage() {}
-
-func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_job_proto_msgTypes[6]
+func (x *SuggestJobsRequest) GetField() SuggestField {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Field
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
-func (*GetJobResponse) Descriptor() ([]byte, []int) {
-	return file_proto_job_proto_rawDescGZIP(), []int{6}
+	return SuggestField_SUGGEST_FIELD_TITLE
 }
 
-func (x *GetJobResponse) GetJob() *Job {
+func (x *SuggestJobsRequest) GetSize() int32 {
 	if x != nil {
-		return x.Job
+		return x.Size
 	}
-	return nil
+	return 0
 }
 
-type DeleteJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+type Suggestion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Text  string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// Relevance for titles and companies, number of jobs for skills.
+	Score         float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteJobRequest) Reset() {
-	*x = DeleteJobRequest{}
-	mi := &file_proto_job_proto_msgTypes[7]
+func (x *Suggestion) Reset() {
+	*x = Suggestion{}
+	mi := &file_proto_job_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteJobRequest) String() string {
+func (x *Suggestion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteJobRequest) ProtoMessage() {}
+func (*Suggestion) ProtoMessage() {}
 
-func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_job_proto_msgTypes[7]
+func (x *Suggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -497,40 +2787,47 @@ func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteJobRequest.ProtoReflect.Descriptor instead.
-func (*DeleteJobRequest) Descriptor() ([]byte, []int) {
-	return file_proto_job_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use Suggestion.ProtoReflect.Descriptor instead.
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *DeleteJobRequest) GetId() string {
+func (x *Suggestion) GetText() string {
 	if x != nil {
-		return x.Id
+		return x.Text
 	}
 	return ""
 }
 
-type DeleteJobResponse struct {
+func (x *Suggestion) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type SuggestJobsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Suggestions   []*Suggestion          `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteJobResponse) Reset() {
-	*x = DeleteJobResponse{}
-	mi := &file_proto_job_proto_msgTypes[8]
+func (x *SuggestJobsResponse) Reset() {
+	*x = SuggestJobsResponse{}
+	mi := &file_proto_job_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteJobResponse) String() string {
+func (x *SuggestJobsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteJobResponse) ProtoMessage() {}
+func (*SuggestJobsResponse) ProtoMessage() {}
 
-func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_job_proto_msgTypes[8]
+func (x *SuggestJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_job_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -541,23 +2838,23 @@ func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteJobResponse.ProtoReflect.Descriptor instead.
-func (*DeleteJobResponse) Descriptor() ([]byte, []int) {
-	return file_proto_job_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use SuggestJobsResponse.ProtoReflect.Descriptor instead.
+func (*SuggestJobsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_job_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *DeleteJobResponse) GetMessage() string {
+func (x *SuggestJobsResponse) GetSuggestions() []*Suggestion {
 	if x != nil {
-		return x.Message
+		return x.Suggestions
 	}
-	return ""
+	return nil
 }
 
 var File_proto_job_proto protoreflect.FileDescriptor
 
 const file_proto_job_proto_rawDesc = "" +
 	"\n" +
-	"\x0fproto/job.proto\x12\x03job\"\xe8\x01\n" +
+	"\x0fproto/job.proto\x12\x03job\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9d\b\n" +
 	"\x03Job\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -568,39 +2865,275 @@ const file_proto_job_proto_rawDesc = "" +
 	"\x06salary\x18\a \x01(\x01R\x06salary\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x14\n" +
-	"\x05score\x18\t \x01(\x01R\x05score\"\xb0\x01\n" +
+	"\x05score\x18\t \x01(\x01R\x05score\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\tR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\v \x01(\tR\aversion\x128\n" +
+	"\n" +
+	"highlights\x18\f \x03(\v2\x18.job.Job.HighlightsEntryR\n" +
+	"highlights\x12\x1f\n" +
+	"\x03geo\x18\r \x01(\v2\r.job.GeoPointR\x03geo\x12\x1f\n" +
+	"\vdistance_km\x18\x0e \x01(\x01R\n" +
+	"distanceKm\x12*\n" +
+	"\twork_mode\x18\x0f \x01(\x0e2\r.job.WorkModeR\bworkMode\x126\n" +
+	"\rremote_policy\x18\x10 \x01(\v2\x11.job.RemotePolicyR\fremotePolicy\x12\x1d\n" +
+	"\n" +
+	"salary_min\x18\x11 \x01(\x01R\tsalaryMin\x12\x1d\n" +
+	"\n" +
+	"salary_max\x18\x12 \x01(\x01R\tsalaryMax\x12\x1a\n" +
+	"\bcurrency\x18\x13 \x01(\tR\bcurrency\x12-\n" +
+	"\n" +
+	"pay_period\x18\x14 \x01(\x0e2\x0e.job.PayPeriodR\tpayPeriod\x12<\n" +
+	"\x0femployment_type\x18\x15 \x01(\x0e2\x13.job.EmploymentTypeR\x0eemploymentType\x12,\n" +
+	"\tseniority\x18\x16 \x01(\x0e2\x0e.job.SeniorityR\tseniority\x12*\n" +
+	"\x11annual_salary_min\x18\x17 \x01(\x01R\x0fannualSalaryMin\x12*\n" +
+	"\x11annual_salary_max\x18\x18 \x01(\x01R\x0fannualSalaryMax\x12%\n" +
+	"\x0ematched_skills\x18\x19 \x03(\tR\rmatchedSkills\x12\x1a\n" +
+	"\bfeatured\x18\x1a \x01(\bR\bfeatured\x122\n" +
+	"\vexplanation\x18\x1b \x01(\v2\x10.job.ExplanationR\vexplanation\x1aV\n" +
+	"\x0fHighlightsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
+	"\x05value\x18\x02 \x01(\v2\x17.job.HighlightFragmentsR\x05value:\x028\x01\"q\n" +
+	"\vExplanation\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x01R\x05value\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12*\n" +
+	"\adetails\x18\x03 \x03(\v2\x10.job.ExplanationR\adetails\"\xe5\x01\n" +
+	"\fRemotePolicy\x12+\n" +
+	"\x11allowed_countries\x18\x01 \x03(\tR\x10allowedCountries\x128\n" +
+	"\x16min_utc_offset_minutes\x18\x02 \x01(\x05H\x00R\x13minUtcOffsetMinutes\x88\x01\x01\x128\n" +
+	"\x16max_utc_offset_minutes\x18\x03 \x01(\x05H\x01R\x13maxUtcOffsetMinutes\x88\x01\x01B\x19\n" +
+	"\x17_min_utc_offset_minutesB\x19\n" +
+	"\x17_max_utc_offset_minutes\".\n" +
+	"\bGeoPoint\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x02 \x01(\x01R\x03lon\"2\n" +
+	"\x12HighlightFragments\x12\x1c\n" +
+	"\tfragments\x18\x01 \x03(\tR\tfragments\"\xc6\x04\n" +
 	"\x10CreateJobRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
 	"\acompany\x18\x03 \x01(\tR\acompany\x12\x1a\n" +
 	"\blocation\x18\x04 \x01(\tR\blocation\x12\x16\n" +
 	"\x06skills\x18\x05 \x03(\tR\x06skills\x12\x16\n" +
-	"\x06salary\x18\x06 \x01(\x01R\x06salary\"=\n" +
+	"\x06salary\x18\x06 \x01(\x01R\x06salary\x12\x1f\n" +
+	"\x03geo\x18\a \x01(\v2\r.job.GeoPointR\x03geo\x12*\n" +
+	"\twork_mode\x18\b \x01(\x0e2\r.job.WorkModeR\bworkMode\x126\n" +
+	"\rremote_policy\x18\t \x01(\v2\x11.job.RemotePolicyR\fremotePolicy\x12\x1d\n" +
+	"\n" +
+	"salary_min\x18\n" +
+	" \x01(\x01R\tsalaryMin\x12\x1d\n" +
+	"\n" +
+	"salary_max\x18\v \x01(\x01R\tsalaryMax\x12\x1a\n" +
+	"\bcurrency\x18\f \x01(\tR\bcurrency\x12-\n" +
+	"\n" +
+	"pay_period\x18\r \x01(\x0e2\x0e.job.PayPeriodR\tpayPeriod\x12<\n" +
+	"\x0femployment_type\x18\x0e \x01(\x0e2\x13.job.EmploymentTypeR\x0eemploymentType\x12,\n" +
+	"\tseniority\x18\x0f \x01(\x0e2\x0e.job.SeniorityR\tseniority\x12\x1a\n" +
+	"\bfeatured\x18\x10 \x01(\bR\bfeatured\"=\n" +
 	"\x11CreateJobResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"]\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x94\x01\n" +
+	"\x16BulkCreateJobsResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.job.BulkCreateJobResultR\aresults\x12#\n" +
+	"\rcreated_count\x18\x02 \x01(\x05R\fcreatedCount\x12!\n" +
+	"\ffailed_count\x18\x03 \x01(\x05R\vfailedCount\"j\n" +
+	"\x13BulkCreateJobResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12-\n" +
+	"\x05error\x18\x03 \x01(\v2\x17.job.BulkCreateJobErrorR\x05error\"\x9a\x01\n" +
+	"\x12BulkCreateJobError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12>\n" +
+	"\x10field_violations\x18\x04 \x03(\v2\x13.job.FieldViolationR\x0ffieldViolations\"H\n" +
+	"\x0eFieldViolation\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"\x9c\t\n" +
 	"\x11SearchJobsRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
 	"\blocation\x18\x02 \x01(\tR\blocation\x12\x16\n" +
-	"\x06skills\x18\x03 \x03(\tR\x06skills\"H\n" +
+	"\x06skills\x18\x03 \x03(\tR\x06skills\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\x12#\n" +
+	"\x04sort\x18\x06 \x03(\v2\x0f.job.SortOptionR\x04sort\x12\"\n" +
+	"\n" +
+	"min_salary\x18\a \x01(\x01H\x00R\tminSalary\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_salary\x18\b \x01(\x01H\x01R\tmaxSalary\x88\x01\x01\x12=\n" +
+	"\fposted_after\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vpostedAfter\x12?\n" +
+	"\rposted_before\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\fpostedBefore\x12)\n" +
+	"\x06facets\x18\v \x03(\v2\x11.job.FacetRequestR\x06facets\x123\n" +
+	"\thighlight\x18\f \x01(\v2\x15.job.HighlightOptionsR\thighlight\x12!\n" +
+	"\fauto_correct\x18\r \x01(\bR\vautoCorrect\x129\n" +
+	"\fgeo_distance\x18\x0e \x01(\v2\x16.job.GeoDistanceFilterR\vgeoDistance\x12,\n" +
+	"\n" +
+	"work_modes\x18\x0f \x03(\x0e2\r.job.WorkModeR\tworkModes\x12%\n" +
+	"\x0eremote_country\x18\x10 \x01(\tR\rremoteCountry\x12>\n" +
+	"\x19remote_utc_offset_minutes\x18\x11 \x01(\x05H\x02R\x16remoteUtcOffsetMinutes\x88\x01\x01\x12>\n" +
+	"\x10employment_types\x18\x12 \x03(\x0e2\x13.job.EmploymentTypeR\x0femploymentTypes\x120\n" +
+	"\vseniorities\x18\x13 \x03(\x0e2\x0e.job.SeniorityR\vseniorities\x12'\n" +
+	"\x0fsalary_currency\x18\x14 \x01(\tR\x0esalaryCurrency\x123\n" +
+	"\rsalary_period\x18\x15 \x01(\x0e2\x0e.job.PayPeriodR\fsalaryPeriod\x12@\n" +
+	"\x11skills_match_mode\x18\x16 \x01(\x0e2\x14.job.SkillsMatchModeR\x0fskillsMatchMode\x120\n" +
+	"\x14skills_minimum_match\x18\x17 \x01(\x05R\x12skillsMinimumMatch\x12'\n" +
+	"\x0franking_profile\x18\x18 \x01(\tR\x0erankingProfile\x12\x18\n" +
+	"\aexplain\x18\x19 \x01(\bR\aexplainB\r\n" +
+	"\v_min_salaryB\r\n" +
+	"\v_max_salaryB\x1c\n" +
+	"\x1a_remote_utc_offset_minutes\"\x80\x01\n" +
+	"\x11GeoDistanceFilter\x12%\n" +
+	"\x06origin\x18\x01 \x01(\v2\r.job.GeoPointR\x06origin\x12'\n" +
+	"\x0forigin_location\x18\x02 \x01(\tR\x0eoriginLocation\x12\x1b\n" +
+	"\tradius_km\x18\x03 \x01(\x01R\bradiusKm\"\x9b\x01\n" +
+	"\x10HighlightOptions\x12\x17\n" +
+	"\apre_tag\x18\x01 \x01(\tR\x06preTag\x12\x19\n" +
+	"\bpost_tag\x18\x02 \x01(\tR\apostTag\x12#\n" +
+	"\rfragment_size\x18\x03 \x01(\x05R\ffragmentSize\x12.\n" +
+	"\x13number_of_fragments\x18\x04 \x01(\x05R\x11numberOfFragments\"X\n" +
+	"\n" +
+	"SortOption\x12$\n" +
+	"\x05field\x18\x01 \x01(\x0e2\x0e.job.SortFieldR\x05field\x12$\n" +
+	"\x05order\x18\x02 \x01(\x0e2\x0e.job.SortOrderR\x05order\"\xcc\x02\n" +
 	"\x12SearchJobsResponse\x12\x1c\n" +
 	"\x04jobs\x18\x01 \x03(\v2\b.job.JobR\x04jobs\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"\x1f\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\x12;\n" +
+	"\x06facets\x18\x04 \x03(\v2#.job.SearchJobsResponse.FacetsEntryR\x06facets\x12'\n" +
+	"\x0fsuggested_query\x18\x05 \x01(\tR\x0esuggestedQuery\x12'\n" +
+	"\x0fquery_corrected\x18\x06 \x01(\bR\x0equeryCorrected\x1aK\n" +
+	"\vFacetsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12&\n" +
+	"\x05value\x18\x02 \x01(\v2\x10.job.FacetResultR\x05value:\x028\x01\"\x92\x01\n" +
+	"\fFacetRequest\x12%\n" +
+	"\x05field\x18\x01 \x01(\x0e2\x0f.job.FacetFieldR\x05field\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12\x1a\n" +
+	"\binterval\x18\x03 \x01(\x01R\binterval\x12+\n" +
+	"\x11calendar_interval\x18\x04 \x01(\tR\x10calendarInterval\"5\n" +
+	"\vFacetBucket\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"9\n" +
+	"\vFacetResult\x12*\n" +
+	"\abuckets\x18\x01 \x03(\v2\x10.job.FacetBucketR\abuckets\"\x1f\n" +
 	"\rGetJobRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
 	"\x0eGetJobResponse\x12\x1a\n" +
-	"\x03job\x18\x01 \x01(\v2\b.job.JobR\x03job\"\"\n" +
+	"\x03job\x18\x01 \x01(\v2\b.job.JobR\x03job\"<\n" +
 	"\x10DeleteJobRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"-\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"-\n" +
 	"\x11DeleteJobResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2\xf6\x01\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xf0\x04\n" +
+	"\x10UpdateJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\acompany\x18\x04 \x01(\tR\acompany\x12\x1a\n" +
+	"\blocation\x18\x05 \x01(\tR\blocation\x12\x16\n" +
+	"\x06skills\x18\x06 \x03(\tR\x06skills\x12\x16\n" +
+	"\x06salary\x18\a \x01(\x01R\x06salary\x12\x18\n" +
+	"\aversion\x18\b \x01(\tR\aversion\x12\x1f\n" +
+	"\x03geo\x18\t \x01(\v2\r.job.GeoPointR\x03geo\x12*\n" +
+	"\twork_mode\x18\n" +
+	" \x01(\x0e2\r.job.WorkModeR\bworkMode\x126\n" +
+	"\rremote_policy\x18\v \x01(\v2\x11.job.RemotePolicyR\fremotePolicy\x12\x1d\n" +
+	"\n" +
+	"salary_min\x18\f \x01(\x01R\tsalaryMin\x12\x1d\n" +
+	"\n" +
+	"salary_max\x18\r \x01(\x01R\tsalaryMax\x12\x1a\n" +
+	"\bcurrency\x18\x0e \x01(\tR\bcurrency\x12-\n" +
+	"\n" +
+	"pay_period\x18\x0f \x01(\x0e2\x0e.job.PayPeriodR\tpayPeriod\x12<\n" +
+	"\x0femployment_type\x18\x10 \x01(\x0e2\x13.job.EmploymentTypeR\x0eemploymentType\x12,\n" +
+	"\tseniority\x18\x11 \x01(\x0e2\x0e.job.SeniorityR\tseniority\x12\x1a\n" +
+	"\bfeatured\x18\x12 \x01(\bR\bfeatured\"I\n" +
+	"\x11UpdateJobResponse\x12\x1a\n" +
+	"\x03job\x18\x01 \x01(\v2\b.job.JobR\x03job\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x94\x01\n" +
+	"\x0fPatchJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\x03job\x18\x02 \x01(\v2\b.job.JobR\x03job\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\"H\n" +
+	"\x10PatchJobResponse\x12\x1a\n" +
+	"\x03job\x18\x01 \x01(\v2\b.job.JobR\x03job\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"i\n" +
+	"\x12SuggestJobsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12'\n" +
+	"\x05field\x18\x02 \x01(\x0e2\x11.job.SuggestFieldR\x05field\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\"6\n" +
+	"\n" +
+	"Suggestion\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"H\n" +
+	"\x13SuggestJobsResponse\x121\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x0f.job.SuggestionR\vsuggestions*m\n" +
+	"\tPayPeriod\x12\x1a\n" +
+	"\x16PAY_PERIOD_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11PAY_PERIOD_HOURLY\x10\x01\x12\x16\n" +
+	"\x12PAY_PERIOD_MONTHLY\x10\x02\x12\x15\n" +
+	"\x11PAY_PERIOD_YEARLY\x10\x03*\xad\x01\n" +
+	"\x0eEmploymentType\x12\x1f\n" +
+	"\x1bEMPLOYMENT_TYPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19EMPLOYMENT_TYPE_FULL_TIME\x10\x01\x12\x1d\n" +
+	"\x19EMPLOYMENT_TYPE_PART_TIME\x10\x02\x12\x1c\n" +
+	"\x18EMPLOYMENT_TYPE_CONTRACT\x10\x03\x12\x1e\n" +
+	"\x1aEMPLOYMENT_TYPE_INTERNSHIP\x10\x04*\x92\x01\n" +
+	"\tSeniority\x12\x19\n" +
+	"\x15SENIORITY_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10SENIORITY_JUNIOR\x10\x01\x12\x11\n" +
+	"\rSENIORITY_MID\x10\x02\x12\x14\n" +
+	"\x10SENIORITY_SENIOR\x10\x03\x12\x12\n" +
+	"\x0eSENIORITY_LEAD\x10\x04\x12\x17\n" +
+	"\x13SENIORITY_PRINCIPAL\x10\x05*g\n" +
+	"\bWorkMode\x12\x19\n" +
+	"\x15WORK_MODE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10WORK_MODE_ONSITE\x10\x01\x12\x14\n" +
+	"\x10WORK_MODE_HYBRID\x10\x02\x12\x14\n" +
+	"\x10WORK_MODE_REMOTE\x10\x03*i\n" +
+	"\x0fSkillsMatchMode\x12\x19\n" +
+	"\x15SKILLS_MATCH_MODE_ANY\x10\x00\x12\x19\n" +
+	"\x15SKILLS_MATCH_MODE_ALL\x10\x01\x12 \n" +
+	"\x1cSKILLS_MATCH_MODE_AT_LEAST_N\x10\x02*\x9e\x01\n" +
+	"\tSortField\x12\x18\n" +
+	"\x14SORT_FIELD_RELEVANCE\x10\x00\x12\x19\n" +
+	"\x15SORT_FIELD_CREATED_AT\x10\x01\x12\x15\n" +
+	"\x11SORT_FIELD_SALARY\x10\x02\x12\x14\n" +
+	"\x10SORT_FIELD_TITLE\x10\x03\x12\x16\n" +
+	"\x12SORT_FIELD_COMPANY\x10\x04\x12\x17\n" +
+	"\x13SORT_FIELD_DISTANCE\x10\x05*L\n" +
+	"\tSortOrder\x12\x16\n" +
+	"\x12SORT_ORDER_DEFAULT\x10\x00\x12\x12\n" +
+	"\x0eSORT_ORDER_ASC\x10\x01\x12\x13\n" +
+	"\x0fSORT_ORDER_DESC\x10\x02*\xe4\x01\n" +
+	"\n" +
+	"FacetField\x12\x1b\n" +
+	"\x17FACET_FIELD_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12FACET_FIELD_SKILLS\x10\x01\x12\x18\n" +
+	"\x14FACET_FIELD_LOCATION\x10\x02\x12\x17\n" +
+	"\x13FACET_FIELD_COMPANY\x10\x03\x12\x16\n" +
+	"\x12FACET_FIELD_SALARY\x10\x04\x12\x1a\n" +
+	"\x16FACET_FIELD_CREATED_AT\x10\x05\x12\x1f\n" +
+	"\x1bFACET_FIELD_EMPLOYMENT_TYPE\x10\x06\x12\x19\n" +
+	"\x15FACET_FIELD_SENIORITY\x10\a*[\n" +
+	"\fSuggestField\x12\x17\n" +
+	"\x13SUGGEST_FIELD_TITLE\x10\x00\x12\x19\n" +
+	"\x15SUGGEST_FIELD_COMPANY\x10\x01\x12\x17\n" +
+	"\x13SUGGEST_FIELD_SKILL\x10\x022\xf5\x03\n" +
 	"\n" +
 	"JobService\x12:\n" +
-	"\tCreateJob\x12\x15.job.CreateJobRequest\x1a\x16.job.CreateJobResponse\x12=\n" +
+	"\tCreateJob\x12\x15.job.CreateJobRequest\x1a\x16.job.CreateJobResponse\x12F\n" +
+	"\x0eBulkCreateJobs\x12\x15.job.CreateJobRequest\x1a\x1b.job.BulkCreateJobsResponse(\x01\x12=\n" +
 	"\n" +
 	"SearchJobs\x12\x16.job.SearchJobsRequest\x1a\x17.job.SearchJobsResponse\x121\n" +
 	"\x06GetJob\x12\x12.job.GetJobRequest\x1a\x13.job.GetJobResponse\x12:\n" +
-	"\tDeleteJob\x12\x15.job.DeleteJobRequest\x1a\x16.job.DeleteJobResponseB\x1eZ\x1cjob-search-service/proto/jobb\x06proto3"
+	"\tDeleteJob\x12\x15.job.DeleteJobRequest\x1a\x16.job.DeleteJobResponse\x12:\n" +
+	"\tUpdateJob\x12\x15.job.UpdateJobRequest\x1a\x16.job.UpdateJobResponse\x127\n" +
+	"\bPatchJob\x12\x14.job.PatchJobRequest\x1a\x15.job.PatchJobResponse\x12@\n" +
+	"\vSuggestJobs\x12\x17.job.SuggestJobsRequest\x1a\x18.job.SuggestJobsResponseB\x1eZ\x1cjob-search-service/proto/jobb\x06proto3"
 
 var (
 	file_proto_job_proto_rawDescOnce sync.Once
@@ -614,34 +3147,126 @@ func file_proto_job_proto_rawDescGZIP() []byte {
 	return file_proto_job_proto_rawDescData
 }
 
-var file_proto_job_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_job_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_proto_job_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_proto_job_proto_goTypes = []any{
-	(*Job)(nil),                // 0: job.Job
-	(*CreateJobRequest)(nil),   // 1: job.CreateJobRequest
-	(*CreateJobResponse)(nil),  // 2: job.CreateJobResponse
-	(*SearchJobsRequest)(nil),  // 3: job.SearchJobsRequest
-	(*SearchJobsResponse)(nil), // 4: job.SearchJobsResponse
-	(*GetJobRequest)(nil),      // 5: job.GetJobRequest
-	(*GetJobResponse)(nil),     // 6: job.GetJobResponse
-	(*DeleteJobRequest)(nil),   // 7: job.DeleteJobRequest
-	(*DeleteJobResponse)(nil),  // 8: job.DeleteJobResponse
+	(PayPeriod)(0),                 // 0: job.PayPeriod
+	(EmploymentType)(0),            // 1: job.EmploymentType
+	(Seniority)(0),                 // 2: job.Seniority
+	(WorkMode)(0),                  // 3: job.WorkMode
+	(SkillsMatchMode)(0),           // 4: job.SkillsMatchMode
+	(SortField)(0),                 // 5: job.SortField
+	(SortOrder)(0),                 // 6: job.SortOrder
+	(FacetField)(0),                // 7: job.FacetField
+	(SuggestField)(0),              // 8: job.SuggestField
+	(*Job)(nil),                    // 9: job.Job
+	(*Explanation)(nil),            // 10: job.Explanation
+	(*RemotePolicy)(nil),           // 11: job.RemotePolicy
+	(*GeoPoint)(nil),               // 12: job.GeoPoint
+	(*HighlightFragments)(nil),     // 13: job.HighlightFragments
+	(*CreateJobRequest)(nil),       // 14: job.CreateJobRequest
+	(*CreateJobResponse)(nil),      // 15: job.CreateJobResponse
+	(*BulkCreateJobsResponse)(nil), // 16: job.BulkCreateJobsResponse
+	(*BulkCreateJobResult)(nil),    // 17: job.BulkCreateJobResult
+	(*BulkCreateJobError)(nil),     // 18: job.BulkCreateJobError
+	(*FieldViolation)(nil),         // 19: job.FieldViolation
+	(*SearchJobsRequest)(nil),      // 20: job.SearchJobsRequest
+	(*GeoDistanceFilter)(nil),      // 21: job.GeoDistanceFilter
+	(*HighlightOptions)(nil),       // 22: job.HighlightOptions
+	(*SortOption)(nil),             // 23: job.SortOption
+	(*SearchJobsResponse)(nil),     // 24: job.SearchJobsResponse
+	(*FacetRequest)(nil),           // 25: job.FacetRequest
+	(*FacetBucket)(nil),            // 26: job.FacetBucket
+	(*FacetResult)(nil),            // 27: job.FacetResult
+	(*GetJobRequest)(nil),          // 28: job.GetJobRequest
+	(*GetJobResponse)(nil),         // 29: job.GetJobResponse
+	(*DeleteJobRequest)(nil),       // 30: job.DeleteJobRequest
+	(*DeleteJobResponse)(nil),      // 31: job.DeleteJobResponse
+	(*UpdateJobRequest)(nil),       // 32: job.UpdateJobRequest
+	(*UpdateJobResponse)(nil),      // 33: job.UpdateJobResponse
+	(*PatchJobRequest)(nil),        // 34: job.PatchJobRequest
+	(*PatchJobResponse)(nil),       // 35: job.PatchJobResponse
+	(*SuggestJobsRequest)(nil),     // 36: job.SuggestJobsRequest
+	(*Suggestion)(nil),             // 37: job.Suggestion
+	(*SuggestJobsResponse)(nil),    // 38: job.SuggestJobsResponse
+	nil,                            // 39: job.Job.HighlightsEntry
+	nil,                            // 40: job.SearchJobsResponse.FacetsEntry
+	(*timestamppb.Timestamp)(nil),  // 41: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),  // 42: google.protobuf.FieldMask
 }
 var file_proto_job_proto_depIdxs = []int32{
-	0, // 0: job.SearchJobsResponse.jobs:type_name -> job.Job
-	0, // 1: job.GetJobResponse.job:type_name -> job.Job
-	1, // 2: job.JobService.CreateJob:input_type -> job.CreateJobRequest
-	3, // 3: job.JobService.SearchJobs:input_type -> job.SearchJobsRequest
-	5, // 4: job.JobService.GetJob:input_type -> job.GetJobRequest
-	7, // 5: job.JobService.DeleteJob:input_type -> job.DeleteJobRequest
-	2, // 6: job.JobService.CreateJob:output_type -> job.CreateJobResponse
-	4, // 7: job.JobService.SearchJobs:output_type -> job.SearchJobsResponse
-	6, // 8: job.JobService.GetJob:output_type -> job.GetJobResponse
-	8, // 9: job.JobService.DeleteJob:output_type -> job.DeleteJobResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	39, // 0: job.Job.highlights:type_name -> job.Job.HighlightsEntry
+	12, // 1: job.Job.geo:type_name -> job.GeoPoint
+	3,  // 2: job.Job.work_mode:type_name -> job.WorkMode
+	11, // 3: job.Job.remote_policy:type_name -> job.RemotePolicy
+	0,  // 4: job.Job.pay_period:type_name -> job.PayPeriod
+	1,  // 5: job.Job.employment_type:type_name -> job.EmploymentType
+	2,  // 6: job.Job.seniority:type_name -> job.Seniority
+	10, // 7: job.Job.explanation:type_name -> job.Explanation
+	10, // 8: job.Explanation.details:type_name -> job.Explanation
+	12, // 9: job.CreateJobRequest.geo:type_name -> job.GeoPoint
+	3,  // 10: job.CreateJobRequest.work_mode:type_name -> job.WorkMode
+	11, // 11: job.CreateJobRequest.remote_policy:type_name -> job.RemotePolicy
+	0,  // 12: job.CreateJobRequest.pay_period:type_name -> job.PayPeriod
+	1,  // 13: job.CreateJobRequest.employment_type:type_name -> job.EmploymentType
+	2,  // 14: job.CreateJobRequest.seniority:type_name -> job.Seniority
+	17, // 15: job.BulkCreateJobsResponse.results:type_name -> job.BulkCreateJobResult
+	18, // 16: job.BulkCreateJobResult.error:type_name -> job.BulkCreateJobError
+	19, // 17: job.BulkCreateJobError.field_violations:type_name -> job.FieldViolation
+	23, // 18: job.SearchJobsRequest.sort:type_name -> job.SortOption
+	41, // 19: job.SearchJobsRequest.posted_after:type_name -> google.protobuf.Timestamp
+	41, // 20: job.SearchJobsRequest.posted_before:type_name -> google.protobuf.Timestamp
+	25, // 21: job.SearchJobsRequest.facets:type_name -> job.FacetRequest
+	22, // 22: job.SearchJobsRequest.highlight:type_name -> job.HighlightOptions
+	21, // 23: job.SearchJobsRequest.geo_distance:type_name -> job.GeoDistanceFilter
+	3,  // 24: job.SearchJobsRequest.work_modes:type_name -> job.WorkMode
+	1,  // 25: job.SearchJobsRequest.employment_types:type_name -> job.EmploymentType
+	2,  // 26: job.SearchJobsRequest.seniorities:type_name -> job.Seniority
+	0,  // 27: job.SearchJobsRequest.salary_period:type_name -> job.PayPeriod
+	4,  // 28: job.SearchJobsRequest.skills_match_mode:type_name -> job.SkillsMatchMode
+	12, // 29: job.GeoDistanceFilter.origin:type_name -> job.GeoPoint
+	5,  // 30: job.SortOption.field:type_name -> job.SortField
+	6,  // 31: job.SortOption.order:type_name -> job.SortOrder
+	9,  // 32: job.SearchJobsResponse.jobs:type_name -> job.Job
+	40, // 33: job.SearchJobsResponse.facets:type_name -> job.SearchJobsResponse.FacetsEntry
+	7,  // 34: job.FacetRequest.field:type_name -> job.FacetField
+	26, // 35: job.FacetResult.buckets:type_name -> job.FacetBucket
+	9,  // 36: job.GetJobResponse.job:type_name -> job.Job
+	12, // 37: job.UpdateJobRequest.geo:type_name -> job.GeoPoint
+	3,  // 38: job.UpdateJobRequest.work_mode:type_name -> job.WorkMode
+	11, // 39: job.UpdateJobRequest.remote_policy:type_name -> job.RemotePolicy
+	0,  // 40: job.UpdateJobRequest.pay_period:type_name -> job.PayPeriod
+	1,  // 41: job.UpdateJobRequest.employment_type:type_name -> job.EmploymentType
+	2,  // 42: job.UpdateJobRequest.seniority:type_name -> job.Seniority
+	9,  // 43: job.UpdateJobResponse.job:type_name -> job.Job
+	9,  // 44: job.PatchJobRequest.job:type_name -> job.Job
+	42, // 45: job.PatchJobRequest.update_mask:type_name -> google.protobuf.FieldMask
+	9,  // 46: job.PatchJobResponse.job:type_name -> job.Job
+	8,  // 47: job.SuggestJobsRequest.field:type_name -> job.SuggestField
+	37, // 48: job.SuggestJobsResponse.suggestions:type_name -> job.Suggestion
+	13, // 49: job.Job.HighlightsEntry.value:type_name -> job.HighlightFragments
+	27, // 50: job.SearchJobsResponse.FacetsEntry.value:type_name -> job.FacetResult
+	14, // 51: job.JobService.CreateJob:input_type -> job.CreateJobRequest
+	14, // 52: job.JobService.BulkCreateJobs:input_type -> job.CreateJobRequest
+	20, // 53: job.JobService.SearchJobs:input_type -> job.SearchJobsRequest
+	28, // 54: job.JobService.GetJob:input_type -> job.GetJobRequest
+	30, // 55: job.JobService.DeleteJob:input_type -> job.DeleteJobRequest
+	32, // 56: job.JobService.UpdateJob:input_type -> job.UpdateJobRequest
+	34, // 57: job.JobService.PatchJob:input_type -> job.PatchJobRequest
+	36, // 58: job.JobService.SuggestJobs:input_type -> job.SuggestJobsRequest
+	15, // 59: job.JobService.CreateJob:output_type -> job.CreateJobResponse
+	16, // 60: job.JobService.BulkCreateJobs:output_type -> job.BulkCreateJobsResponse
+	24, // 61: job.JobService.SearchJobs:output_type -> job.SearchJobsResponse
+	29, // 62: job.JobService.GetJob:output_type -> job.GetJobResponse
+	31, // 63: job.JobService.DeleteJob:output_type -> job.DeleteJobResponse
+	33, // 64: job.JobService.UpdateJob:output_type -> job.UpdateJobResponse
+	35, // 65: job.JobService.PatchJob:output_type -> job.PatchJobResponse
+	38, // 66: job.JobService.SuggestJobs:output_type -> job.SuggestJobsResponse
+	59, // [59:67] is the sub-list for method output_type
+	51, // [51:59] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_proto_job_proto_init() }
@@ -649,18 +3274,21 @@ func file_proto_job_proto_init() {
 	if File_proto_job_proto != nil {
 		return
 	}
+	file_proto_job_proto_msgTypes[2].OneofWrappers = []any{}
+	file_proto_job_proto_msgTypes[11].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_job_proto_rawDesc), len(file_proto_job_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   9,
+			NumEnums:      9,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_job_proto_goTypes,
 		DependencyIndexes: file_proto_job_proto_depIdxs,
+		EnumInfos:         file_proto_job_proto_enumTypes,
 		MessageInfos:      file_proto_job_proto_msgTypes,
 	}.Build()
 	File_proto_job_proto = out.File
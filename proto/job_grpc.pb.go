@@ -19,10 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	JobService_CreateJob_FullMethodName  = "/job.JobService/CreateJob"
-	JobService_SearchJobs_FullMethodName = "/job.JobService/SearchJobs"
-	JobService_GetJob_FullMethodName     = "/job.JobService/GetJob"
-	JobService_DeleteJob_FullMethodName  = "/job.JobService/DeleteJob"
+	JobService_CreateJob_FullMethodName      = "/job.JobService/CreateJob"
+	JobService_BulkCreateJobs_FullMethodName = "/job.JobService/BulkCreateJobs"
+	JobService_SearchJobs_FullMethodName     = "/job.JobService/SearchJobs"
+	JobService_GetJob_FullMethodName         = "/job.JobService/GetJob"
+	JobService_DeleteJob_FullMethodName      = "/job.JobService/DeleteJob"
+	JobService_UpdateJob_FullMethodName      = "/job.JobService/UpdateJob"
+	JobService_PatchJob_FullMethodName       = "/job.JobService/PatchJob"
+	JobService_SuggestJobs_FullMethodName    = "/job.JobService/SuggestJobs"
 )
 
 // JobServiceClient is the client API for JobService service.
@@ -30,9 +34,15 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type JobServiceClient interface {
 	CreateJob(ctx context.Context, in *CreateJobRequest, opts ...grpc.CallOption) (*CreateJobResponse, error)
+	// Creates the jobs of a stream in batches and replies once the client
+	// closes it. A rejected job does not stop the others.
+	BulkCreateJobs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateJobRequest, BulkCreateJobsResponse], error)
 	SearchJobs(ctx context.Context, in *SearchJobsRequest, opts ...grpc.CallOption) (*SearchJobsResponse, error)
 	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error)
 	DeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error)
+	UpdateJob(ctx context.Context, in *UpdateJobRequest, opts ...grpc.CallOption) (*UpdateJobResponse, error)
+	PatchJob(ctx context.Context, in *PatchJobRequest, opts ...grpc.CallOption) (*PatchJobResponse, error)
+	SuggestJobs(ctx context.Context, in *SuggestJobsRequest, opts ...grpc.CallOption) (*SuggestJobsResponse, error)
 }
 
 type jobServiceClient struct {
@@ -53,6 +63,19 @@ func (c *jobServiceClient) CreateJob(ctx context.Context, in *CreateJobRequest,
 	return out, nil
 }
 
+func (c *jobServiceClient) BulkCreateJobs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateJobRequest, BulkCreateJobsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[0], JobService_BulkCreateJobs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CreateJobRequest, BulkCreateJobsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JobService_BulkCreateJobsClient = grpc.ClientStreamingClient[CreateJobRequest, BulkCreateJobsResponse]
+
 func (c *jobServiceClient) SearchJobs(ctx context.Context, in *SearchJobsRequest, opts ...grpc.CallOption) (*SearchJobsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SearchJobsResponse)
@@ -83,14 +106,50 @@ func (c *jobServiceClient) DeleteJob(ctx context.Context, in *DeleteJobRequest,
 	return out, nil
 }
 
+func (c *jobServiceClient) UpdateJob(ctx context.Context, in *UpdateJobRequest, opts ...grpc.CallOption) (*UpdateJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateJobResponse)
+	err := c.cc.Invoke(ctx, JobService_UpdateJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) PatchJob(ctx context.Context, in *PatchJobRequest, opts ...grpc.CallOption) (*PatchJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PatchJobResponse)
+	err := c.cc.Invoke(ctx, JobService_PatchJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) SuggestJobs(ctx context.Context, in *SuggestJobsRequest, opts ...grpc.CallOption) (*SuggestJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestJobsResponse)
+	err := c.cc.Invoke(ctx, JobService_SuggestJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // JobServiceServer is the server API for JobService service.
 // All implementations must embed UnimplementedJobServiceServer
 // for forward compatibility.
 type JobServiceServer interface {
 	CreateJob(context.Context, *CreateJobRequest) (*CreateJobResponse, error)
+	// Creates the jobs of a stream in batches and replies once the client
+	// closes it. A rejected job does not stop the others.
+	BulkCreateJobs(grpc.ClientStreamingServer[CreateJobRequest, BulkCreateJobsResponse]) error
 	SearchJobs(context.Context, *SearchJobsRequest) (*SearchJobsResponse, error)
 	GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error)
 	DeleteJob(context.Context, *DeleteJobRequest) (*DeleteJobResponse, error)
+	UpdateJob(context.Context, *UpdateJobRequest) (*UpdateJobResponse, error)
+	PatchJob(context.Context, *PatchJobRequest) (*PatchJobResponse, error)
+	SuggestJobs(context.Context, *SuggestJobsRequest) (*SuggestJobsResponse, error)
 	mustEmbedUnimplementedJobServiceServer()
 }
 
@@ -104,6 +163,9 @@ type UnimplementedJobServiceServer struct{}
 func (UnimplementedJobServiceServer) CreateJob(context.Context, *CreateJobRequest) (*CreateJobResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateJob not implemented")
 }
+func (UnimplementedJobServiceServer) BulkCreateJobs(grpc.ClientStreamingServer[CreateJobRequest, BulkCreateJobsResponse]) error {
+	return status.Error(codes.Unimplemented, "method BulkCreateJobs not implemented")
+}
 func (UnimplementedJobServiceServer) SearchJobs(context.Context, *SearchJobsRequest) (*SearchJobsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SearchJobs not implemented")
 }
@@ -113,6 +175,15 @@ func (UnimplementedJobServiceServer) GetJob(context.Context, *GetJobRequest) (*G
 func (UnimplementedJobServiceServer) DeleteJob(context.Context, *DeleteJobRequest) (*DeleteJobResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteJob not implemented")
 }
+func (UnimplementedJobServiceServer) UpdateJob(context.Context, *UpdateJobRequest) (*UpdateJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateJob not implemented")
+}
+func (UnimplementedJobServiceServer) PatchJob(context.Context, *PatchJobRequest) (*PatchJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PatchJob not implemented")
+}
+func (UnimplementedJobServiceServer) SuggestJobs(context.Context, *SuggestJobsRequest) (*SuggestJobsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SuggestJobs not implemented")
+}
 func (UnimplementedJobServiceServer) mustEmbedUnimplementedJobServiceServer() {}
 func (UnimplementedJobServiceServer) testEmbeddedByValue()                    {}
 
@@ -152,6 +223,13 @@ func _JobService_CreateJob_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JobService_BulkCreateJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(JobServiceServer).BulkCreateJobs(&grpc.GenericServerStream[CreateJobRequest, BulkCreateJobsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JobService_BulkCreateJobsServer = grpc.ClientStreamingServer[CreateJobRequest, BulkCreateJobsResponse]
+
 func _JobService_SearchJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SearchJobsRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +284,60 @@ func _JobService_DeleteJob_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JobService_UpdateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).UpdateJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_UpdateJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).UpdateJob(ctx, req.(*UpdateJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_PatchJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).PatchJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_PatchJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).PatchJob(ctx, req.(*PatchJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_SuggestJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).SuggestJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_SuggestJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).SuggestJobs(ctx, req.(*SuggestJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // JobService_ServiceDesc is the grpc.ServiceDesc for JobService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,7 +361,25 @@ var JobService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteJob",
 			Handler:    _JobService_DeleteJob_Handler,
 		},
+		{
+			MethodName: "UpdateJob",
+			Handler:    _JobService_UpdateJob_Handler,
+		},
+		{
+			MethodName: "PatchJob",
+			Handler:    _JobService_PatchJob_Handler,
+		},
+		{
+			MethodName: "SuggestJobs",
+			Handler:    _JobService_SuggestJobs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BulkCreateJobs",
+			Handler:       _JobService_BulkCreateJobs_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/job.proto",
 }